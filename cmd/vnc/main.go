@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -12,7 +13,6 @@ import (
 	"strings"
 	"text/tabwriter"
 
-	"github.com/go-vgo/robotgo"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
@@ -35,6 +35,15 @@ var websockifyHost string
 var websockifyPort int32
 var noTCP bool
 var serverPasswordFile string
+var tlsCertFile string
+var tlsKeyFile string
+var vencryptSubtypes []string
+var hostKeyFile string
+var authFile string
+var viewOnlyPasswordFile string
+var allowedNetworks []string
+var deniedNetworks []string
+var auditLogFile string
 
 // RootCmd is the exported root cmd for the go-vnc server.
 var RootCmd = &cobra.Command{
@@ -63,8 +72,17 @@ func init() {
 	RootCmd.PersistentFlags().Int32VarP(&bindPort, "port", "p", 5900, "The port to bind the server to.")
 	RootCmd.PersistentFlags().StringVarP(&initialResolution, "resolution", "r", "", "The initial resolution to set for display connections. Defaults to auto-detect.")
 	RootCmd.PersistentFlags().StringVarP(&serverPasswordFile, "password-file", "", "", "A file to read in a server password from. One will be generated if this is omitted.")
+	RootCmd.PersistentFlags().StringVarP(&tlsCertFile, "tls-cert", "", "", "A PEM certificate file for the X509 subtypes of VeNCrypt.")
+	RootCmd.PersistentFlags().StringVarP(&tlsKeyFile, "tls-key", "", "", "The PEM key file of the certificate given with --tls-cert.")
+	RootCmd.PersistentFlags().StringSliceVarP(&vencryptSubtypes, "vencrypt-subtypes", "", nil, "The VeNCrypt subtypes to offer, such as X509Plain,X509Vnc. The TLS ones are only offered if listed, and X509None if listed or if None is enabled.")
+	RootCmd.PersistentFlags().StringVarP(&hostKeyFile, "host-key", "", "", "A PEM file holding the RSA host key of the RSA-AES security types. It is created if missing.")
+	RootCmd.PersistentFlags().StringVarP(&viewOnlyPasswordFile, "view-only-password-file", "", "", "A file to read in a second password from, which only lets clients watch.")
+	RootCmd.PersistentFlags().StringVarP(&authFile, "auth-file", "", "", "An htpasswd file of users with bcrypt hashed passwords, replacing the server password.")
+	RootCmd.PersistentFlags().StringSliceVarP(&allowedNetworks, "allow", "", nil, "Networks clients may connect from, in CIDR notation. All by default.")
+	RootCmd.PersistentFlags().StringSliceVarP(&deniedNetworks, "deny", "", nil, "Networks clients may not connect from, in CIDR notation.")
+	RootCmd.PersistentFlags().StringVarP(&auditLogFile, "audit-log", "", "", "A file to log refused connections and authentication results to, as JSON. They go to the main log if omitted.")
 	RootCmd.PersistentFlags().BoolVarP(&listFeatures, "list-features", "l", false, "List the available features and exit.")
-	RootCmd.PersistentFlags().StringVarP(&displayProvider, "display", "D", display.ProviderScreenCapture, "The display provider to use for RFB connections.")
+	RootCmd.PersistentFlags().StringVarP(&displayProvider, "display", "D", display.ProviderScreenShot, "The display provider to use for RFB connections.")
 	RootCmd.PersistentFlags().BoolVarP(&websockify, "websockify", "w", false, "Start a websockify listener")
 	RootCmd.PersistentFlags().StringVarP(&websockifyHost, "websockify-host", "W", "127.0.0.1", "The host address to bind the websockify server to.")
 	RootCmd.PersistentFlags().Int32VarP(&websockifyPort, "websockify-port", "P", 8080, "The port to bind the websockify server to.")
@@ -94,7 +112,7 @@ func run(cmd *cobra.Command, args []string) error {
 	// Configure initial display resolution
 	var w, h int
 	if initialResolution == "" {
-		w, h = robotgo.GetScreenSize()
+		w, h = display.DefaultSize(display.Provider(displayProvider))
 		logrus.Infof("detected initial screen resolution of %dx%d", w, h)
 	} else {
 		spl := strings.Split(strings.ToLower(initialResolution), "x")
@@ -135,7 +153,69 @@ func run(cmd *cobra.Command, args []string) error {
 		EnabledEvents:    eventTypes,
 	}
 
-	if authIsEnabled(authTypes, "VNCAuth") {
+	subtypes, err := auth.ParseVeNCryptSubtypes(vencryptSubtypes)
+	if err != nil {
+		return err
+	}
+	opts.VeNCryptSubtypes = subtypes
+
+	if tlsCertFile != "" || tlsKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(tlsCertFile, tlsKeyFile)
+		if err != nil {
+			return err
+		}
+		opts.TLSConfig = &tls.Config{Certificates: []tls.Certificate{cert}}
+	}
+
+	if hostKeyFile != "" {
+		key, err := auth.LoadHostKey(hostKeyFile)
+		if err != nil {
+			return err
+		}
+		logrus.Info("RSA-AES host key fingerprint: ", auth.HostKeyFingerprint(&key.PublicKey))
+		opts.HostKey = key
+	}
+
+	if authFile != "" {
+		a, err := auth.NewHtpasswdAuthenticator(authFile)
+		if err != nil {
+			return err
+		}
+		opts.Authenticator = a
+	}
+
+	allowed, err := rfb.ParseNetworks(allowedNetworks)
+	if err != nil {
+		return err
+	}
+	opts.AllowedNetworks = allowed
+	denied, err := rfb.ParseNetworks(deniedNetworks)
+	if err != nil {
+		return err
+	}
+	opts.DeniedNetworks = denied
+
+	if auditLogFile != "" {
+		f, err := os.OpenFile(auditLogFile, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		logger := logrus.New()
+		logger.Out = f
+		logger.Formatter = &logrus.JSONFormatter{}
+		opts.AuditLog = logger
+	}
+
+	if viewOnlyPasswordFile != "" {
+		passw, err := ioutil.ReadFile(viewOnlyPasswordFile)
+		if err != nil {
+			return err
+		}
+		opts.ViewOnlyPassword = string(passw)
+	}
+
+	if opts.Authenticator == nil && passwordIsNeeded(authTypes) {
 		if serverPasswordFile != "" {
 			passw, err := ioutil.ReadFile(serverPasswordFile)
 			if err != nil {
@@ -239,6 +319,17 @@ func authIsEnabled(tt []auth.Type, name string) bool {
 	return false
 }
 
+// passwordIsNeeded returns true if one of the auth types checks the server
+// password.
+func passwordIsNeeded(tt []auth.Type) bool {
+	for _, name := range []string{"VNCAuth", "VeNCrypt", "RA2", "RA2ne", "RA256", "RAne256", "RA2TwoStep", "RA256TwoStep"} {
+		if authIsEnabled(tt, name) {
+			return true
+		}
+	}
+	return false
+}
+
 func configureAuthTypes(tt []auth.Type, args []string) []auth.Type {
 	for _, arg := range args {
 		if strings.HasPrefix(arg, "-") {
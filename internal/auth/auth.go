@@ -7,7 +7,8 @@ import (
 // Type represents an authentication type.
 type Type interface {
 	Code() uint8
-	Negotiate(wr *buffer.ReadWriter) error
+	// Negotiate authenticates a client on a server and returns who it is.
+	Negotiate(wr *buffer.ReadWriter) (*Principal, error)
 	Response(wr *buffer.ReadWriter) error
 }
 
@@ -16,6 +17,13 @@ var DefaultAuthTypes = []Type{
 	&None{},
 	&VNCAuth{},
 	&TightSecurity{},
+	&VeNCrypt{},
+	&RA2{},
+	&RA2ne{},
+	&RA256{},
+	&RAne256{},
+	&RA2TwoStep{},
+	&RA256TwoStep{},
 }
 
 // GetDefaults returns a slice of the default auth handlers.
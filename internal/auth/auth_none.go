@@ -8,8 +8,10 @@ type None struct{}
 // Code returns the code for no-auth.
 func (a *None) Code() uint8 { return 1 }
 
-// Negotiate immediately returns nil.
-func (a *None) Negotiate(rw *buffer.ReadWriter) error { return nil }
+// Negotiate immediately returns a client with full control.
+func (a *None) Negotiate(rw *buffer.ReadWriter) (*Principal, error) {
+	return &Principal{Permissions: PermAll}, nil
+}
 
 // Client Response also nil
 func (a *None) Response(rw *buffer.ReadWriter) error {
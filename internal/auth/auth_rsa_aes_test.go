@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/suutaku/go-vnc/internal/buffer"
+)
+
+type rsaAESType interface {
+	Type
+	Settings() *RSAAES
+}
+
+var rsaAESTypes = []struct {
+	name string
+	new  func() rsaAESType
+}{
+	{"RA2", func() rsaAESType { return &RA2{} }},
+	{"RA2ne", func() rsaAESType { return &RA2ne{} }},
+	{"RA256", func() rsaAESType { return &RA256{} }},
+	{"RAne256", func() rsaAESType { return &RAne256{} }},
+	{"RA2TwoStep", func() rsaAESType { return &RA2TwoStep{} }},
+	{"RA256TwoStep", func() rsaAESType { return &RA256TwoStep{} }},
+}
+
+// runRSAAES runs the type between a server and a client over a pipe, then
+// checks that both sides still understand each other, encrypted or not.
+func runRSAAES(t *testing.T, server, client rsaAESType) (*Principal, error) {
+	t.Helper()
+	sc, cc := net.Pipe()
+	defer sc.Close()
+	defer cc.Close()
+	deadline := time.Now().Add(10 * time.Second)
+	sc.SetDeadline(deadline)
+	cc.SetDeadline(deadline)
+	srw, crw := buffer.NewReadWriteBuffer(sc), buffer.NewReadWriteBuffer(cc)
+
+	clientErr := make(chan error, 1)
+	go func() {
+		if err := client.Response(crw); err != nil {
+			clientErr <- err
+			return
+		}
+		var result uint32
+		if err := crw.Read(&result); err != nil {
+			clientErr <- err
+			return
+		}
+		crw.Dispatch([]byte{42})
+		clientErr <- nil
+	}()
+
+	principal, err := server.Negotiate(srw)
+	srw.Dispatch([]byte{0, 0, 0, 0})
+	var b uint8
+	if readErr := srw.Read(&b); readErr != nil || b != 42 {
+		t.Fatalf("%T: session after authentication = %d, %v", server, b, readErr)
+	}
+	if err := <-clientErr; err != nil {
+		t.Fatalf("%T: client: %v", client, err)
+	}
+	return principal, err
+}
+
+func TestRSAAES(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, DefaultHostKeyBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range rsaAESTypes {
+		for _, c := range []struct {
+			password string
+			want     Permissions
+			ok       bool
+		}{
+			{"secret", PermAll, true},
+			{"watch", PermViewOnly, true},
+			{"wrong", 0, false},
+		} {
+			server, client := tt.new(), tt.new()
+			server.Settings().HostKey = key
+			server.Settings().Password = "secret"
+			server.Settings().ViewOnlyPassword = "watch"
+			client.Settings().Username = "alice"
+			client.Settings().Password = c.password
+
+			principal, err := runRSAAES(t, server, client)
+			if (err == nil) != c.ok {
+				t.Errorf("%s with %q: err = %v", tt.name, c.password, err)
+				continue
+			}
+			if c.ok && principal.Permissions != c.want {
+				t.Errorf("%s with %q: permissions = %v, want %v", tt.name, c.password, principal.Permissions, c.want)
+			}
+		}
+	}
+}
+
+func TestRSAAESUsers(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, DefaultHostKeyBits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	users := NewStaticAuthenticator(map[string]string{"alice": "pw"})
+	for _, tt := range rsaAESTypes {
+		for _, c := range []struct {
+			username string
+			ok       bool
+		}{
+			{"alice", true},
+			{"bob", false},
+		} {
+			server, client := tt.new(), tt.new()
+			server.Settings().HostKey = key
+			server.Settings().Authenticator = users
+			client.Settings().Username = c.username
+			client.Settings().Password = "pw"
+
+			principal, err := runRSAAES(t, server, client)
+			if (err == nil) != c.ok {
+				t.Errorf("%s as %q: err = %v", tt.name, c.username, err)
+				continue
+			}
+			if c.ok && principal.Username != c.username {
+				t.Errorf("%s as %q: username = %q", tt.name, c.username, principal.Username)
+			}
+		}
+	}
+}
@@ -31,7 +31,9 @@ var TightClientMessages = []types.TightCapability{}
 var TightEncodingCapabilities = []types.TightCapability{
 	{Code: 0, Vendor: "STDV", Signature: "RAW_____"},
 	{Code: 1, Vendor: "STDV", Signature: "COPYRECT"},
+	{Code: 5, Vendor: "STDV", Signature: "HEXTILE_"},
 	{Code: 7, Vendor: "TGHT", Signature: "TIGHT___"},
+	{Code: 16, Vendor: "TRDV", Signature: "ZRLE____"},
 }
 
 // TightSecurity implements Tight security.
@@ -44,9 +46,9 @@ type TightSecurity struct {
 func (t *TightSecurity) Code() uint8 { return 16 }
 
 // Negotiate will negotiate tight security.
-func (t *TightSecurity) Negotiate(rw *buffer.ReadWriter) error {
+func (t *TightSecurity) Negotiate(rw *buffer.ReadWriter) (*Principal, error) {
 	if err := t.negotiateTightTunnel(rw); err != nil {
-		return err
+		return nil, err
 	}
 	return t.negotiateTightAuth(rw)
 }
@@ -96,7 +98,7 @@ func (t *TightSecurity) negotiateTightTunnel(rw *buffer.ReadWriter) error {
 	return nil
 }
 
-func (t *TightSecurity) negotiateTightAuth(rw *buffer.ReadWriter) error {
+func (t *TightSecurity) negotiateTightAuth(rw *buffer.ReadWriter) (*Principal, error) {
 	buf := new(bytes.Buffer)
 	caps := t.getEnabledAuthCaps()
 	utils.Write(buf, uint32(len(caps)))
@@ -111,7 +113,7 @@ func (t *TightSecurity) negotiateTightAuth(rw *buffer.ReadWriter) error {
 
 	authType := t.AuthGetter(uint8(auth))
 	if authType == nil {
-		return fmt.Errorf("client requested unsupported tight auth type: %d", auth)
+		return nil, fmt.Errorf("client requested unsupported tight auth type: %d", auth)
 	}
 	return authType.Negotiate(rw)
 }
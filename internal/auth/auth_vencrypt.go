@@ -0,0 +1,396 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/suutaku/go-vnc/internal/buffer"
+	"github.com/suutaku/go-vnc/internal/utils"
+)
+
+// VeNCrypt subtypes.
+const (
+	VeNCryptTLSNone   uint32 = 257
+	VeNCryptTLSVnc    uint32 = 258
+	VeNCryptTLSPlain  uint32 = 259
+	VeNCryptX509None  uint32 = 260
+	VeNCryptX509Vnc   uint32 = 261
+	VeNCryptX509Plain uint32 = 262
+)
+
+// VeNCryptSubtypes are the supported VeNCrypt subtypes, in the default order of
+// preference.
+var VeNCryptSubtypes = []uint32{
+	VeNCryptX509Plain,
+	VeNCryptX509Vnc,
+	VeNCryptX509None,
+	VeNCryptTLSPlain,
+	VeNCryptTLSVnc,
+	VeNCryptTLSNone,
+}
+
+// VeNCrypt implements the VeNCrypt security type, version 0.2. The connection
+// is wrapped in TLS, then the client authenticates with the subtype's method:
+// none, VNCAuth or a plain username and password.
+// https://github.com/rfbproto/rfbproto/blob/master/rfbproto.rst#vencrypt
+//
+// The X509 subtypes need a certificate in TLSConfig. The TLS subtypes stand for
+// anonymous TLS, which crypto/tls does not implement, so the server presents a
+// self-signed certificate generated at startup instead and clients must not
+// verify it. Standard clients such as TigerVNC and gtk-vnc only negotiate
+// anonymous Diffie-Hellman for these subtypes, so they cannot use them: the
+// TLS subtypes only work with this package's own Response, and a server only
+// offers them when they are listed in Subtypes.
+type VeNCrypt struct {
+	// Subtypes are the subtypes offered by a server, or accepted by a client,
+	// in order of preference. VeNCryptSubtypes if empty, without the TLS ones
+	// on a server.
+	Subtypes []uint32
+	// AllowNone makes a server offer the None subtypes, which ask for no
+	// credentials, when Subtypes is empty. Listing them in Subtypes offers
+	// them too.
+	AllowNone bool
+	// TLSConfig holds the certificate of a server for the X509 subtypes, and
+	// the roots a client verifies it with.
+	TLSConfig *tls.Config
+	// Password is the VNCAuth password of the Vnc subtypes. Without an
+	// Authenticator, a server also accepts it with any username for the Plain
+	// subtypes.
+	Password string
+	// ViewOnlyPassword is accepted like Password but only lets the client
+	// watch.
+	ViewOnlyPassword string
+	// Username is the username a client sends for the Plain subtypes.
+	Username string
+	// Authenticator checks the usernames and passwords of the Plain subtypes
+	// on a server, and replaces Password for the Vnc subtypes as it does for
+	// VNCAuth.
+	Authenticator Authenticator
+}
+
+// Code returns the code for VeNCrypt.
+func (a *VeNCrypt) Code() uint8 { return 19 }
+
+// Negotiate runs VeNCrypt on a server.
+func (a *VeNCrypt) Negotiate(rw *buffer.ReadWriter) (*Principal, error) {
+	rw.Dispatch([]byte{0, 2})
+	var major, minor uint8
+	if err := rw.Read(&major); err != nil {
+		return nil, err
+	}
+	if err := rw.Read(&minor); err != nil {
+		return nil, err
+	}
+	if major != 0 || minor != 2 {
+		rw.Dispatch([]byte{1})
+		return nil, fmt.Errorf("client requested unsupported VeNCrypt version %d.%d", major, minor)
+	}
+
+	subtypes := a.ServerSubtypes()
+	buf := new(bytes.Buffer)
+	utils.Write(buf, uint8(0)) // version accepted
+	utils.Write(buf, uint8(len(subtypes)))
+	for _, st := range subtypes {
+		utils.Write(buf, st)
+	}
+	rw.Dispatch(buf.Bytes())
+
+	var subtype uint32
+	if err := rw.Read(&subtype); err != nil {
+		return nil, err
+	}
+	if !containsSubtype(subtypes, subtype) {
+		rw.Dispatch([]byte{0})
+		return nil, fmt.Errorf("client requested unsupported VeNCrypt subtype %d", subtype)
+	}
+	logrus.Info("Using VeNCrypt subtype: ", subtypeName(subtype))
+
+	cfg, err := a.serverTLSConfig(subtype)
+	if err != nil {
+		return nil, err
+	}
+	rw.Dispatch([]byte{1}) // go ahead with the TLS handshake
+	err = rw.Wrap(func(c net.Conn) (net.Conn, error) {
+		tc := tls.Server(c, cfg)
+		return tc, tc.Handshake()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch subtype {
+	case VeNCryptTLSVnc, VeNCryptX509Vnc:
+		vncAuth := &VNCAuth{Password: a.Password, ViewOnlyPassword: a.ViewOnlyPassword, Authenticator: a.Authenticator}
+		return vncAuth.Negotiate(rw)
+	case VeNCryptTLSPlain, VeNCryptX509Plain:
+		return a.negotiatePlain(rw)
+	}
+	return &Principal{Permissions: PermAll}, nil
+}
+
+// Response runs VeNCrypt on a client.
+func (a *VeNCrypt) Response(rw *buffer.ReadWriter) error {
+	var major, minor uint8
+	if err := rw.Read(&major); err != nil {
+		return err
+	}
+	if err := rw.Read(&minor); err != nil {
+		return err
+	}
+	if major != 0 || minor < 2 {
+		return fmt.Errorf("server offered unsupported VeNCrypt version %d.%d", major, minor)
+	}
+	rw.Dispatch([]byte{0, 2})
+	var status, n uint8
+	if err := rw.Read(&status); err != nil {
+		return err
+	}
+	if status != 0 {
+		return errors.New("server refused VeNCrypt version 0.2")
+	}
+	if err := rw.Read(&n); err != nil {
+		return err
+	}
+	offered := make([]uint32, n)
+	if err := rw.Read(offered); err != nil {
+		return err
+	}
+
+	var subtype uint32
+	for _, st := range a.subtypes() {
+		if containsSubtype(offered, st) {
+			subtype = st
+			break
+		}
+	}
+	if subtype == 0 {
+		return fmt.Errorf("server offered no accepted VeNCrypt subtype: %v", offered)
+	}
+	buf := new(bytes.Buffer)
+	utils.Write(buf, subtype)
+	rw.Dispatch(buf.Bytes())
+	var ack uint8
+	if err := rw.Read(&ack); err != nil {
+		return err
+	}
+	if ack != 1 {
+		return fmt.Errorf("server refused VeNCrypt subtype %s", subtypeName(subtype))
+	}
+
+	cfg := &tls.Config{}
+	if a.TLSConfig != nil {
+		cfg = a.TLSConfig.Clone()
+	}
+	if !isX509(subtype) {
+		cfg.InsecureSkipVerify = true
+	}
+	err := rw.Wrap(func(c net.Conn) (net.Conn, error) {
+		tc := tls.Client(c, cfg)
+		return tc, tc.Handshake()
+	})
+	if err != nil {
+		return err
+	}
+
+	switch subtype {
+	case VeNCryptTLSVnc, VeNCryptX509Vnc:
+		return (&VNCAuth{Password: a.Password}).Response(rw)
+	case VeNCryptTLSPlain, VeNCryptX509Plain:
+		buf := new(bytes.Buffer)
+		utils.Write(buf, uint32(len(a.Username)))
+		utils.Write(buf, uint32(len(a.Password)))
+		utils.Write(buf, []byte(a.Username))
+		utils.Write(buf, []byte(a.Password))
+		rw.Dispatch(buf.Bytes())
+	}
+	return nil
+}
+
+// Largest username or password accepted by the Plain subtypes.
+const maxPlainLength = 1024
+
+func (a *VeNCrypt) negotiatePlain(rw *buffer.ReadWriter) (*Principal, error) {
+	var userLen, passLen uint32
+	if err := rw.Read(&userLen); err != nil {
+		return nil, err
+	}
+	if err := rw.Read(&passLen); err != nil {
+		return nil, err
+	}
+	if userLen > maxPlainLength || passLen > maxPlainLength {
+		return nil, errors.New("username or password is too long")
+	}
+	username := make([]byte, userLen)
+	if err := rw.Read(username); err != nil {
+		return nil, err
+	}
+	password := make([]byte, passLen)
+	if err := rw.Read(password); err != nil {
+		return nil, err
+	}
+
+	if a.Authenticator != nil {
+		return authenticate(a.Authenticator, string(username), string(password))
+	}
+	return checkSharedPassword(password, a.Password, a.ViewOnlyPassword)
+}
+
+func (a *VeNCrypt) subtypes() []uint32 {
+	if len(a.Subtypes) > 0 {
+		return a.Subtypes
+	}
+	return VeNCryptSubtypes
+}
+
+// ServerSubtypes returns the subtypes a server offers: the X509 ones need a
+// certificate, and the Plain ones a password to check. The TLS subtypes are
+// only offered when listed in Subtypes, since standard clients cannot use
+// them.
+func (a *VeNCrypt) ServerSubtypes() []uint32 {
+	hasCert := a.TLSConfig != nil && (len(a.TLSConfig.Certificates) > 0 || a.TLSConfig.GetCertificate != nil)
+	out := make([]uint32, 0)
+	for _, st := range a.subtypes() {
+		if !containsSubtype(VeNCryptSubtypes, st) {
+			continue
+		}
+		if isX509(st) && !hasCert {
+			continue
+		}
+		if !isX509(st) && len(a.Subtypes) == 0 {
+			continue
+		}
+		if isNoneSubtype(st) && len(a.Subtypes) == 0 && !a.AllowNone {
+			continue
+		}
+		if (st == VeNCryptTLSPlain || st == VeNCryptX509Plain) && a.Authenticator == nil && a.Password == "" {
+			continue
+		}
+		out = append(out, st)
+	}
+	return out
+}
+
+func (a *VeNCrypt) serverTLSConfig(subtype uint32) (*tls.Config, error) {
+	cfg := &tls.Config{}
+	if a.TLSConfig != nil {
+		cfg = a.TLSConfig.Clone()
+	}
+	if cfg.MinVersion == 0 {
+		cfg.MinVersion = tls.VersionTLS12
+	}
+	if !isX509(subtype) {
+		cert, err := anonymousCertificate()
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{*cert}
+		cfg.GetCertificate = nil
+	}
+	return cfg, nil
+}
+
+var (
+	anonCertOnce sync.Once
+	anonCert     *tls.Certificate
+	anonCertErr  error
+)
+
+// anonymousCertificate returns the self-signed certificate presented for the
+// TLS subtypes. It is generated once per process.
+func anonymousCertificate() (*tls.Certificate, error) {
+	anonCertOnce.Do(func() {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			anonCertErr = err
+			return
+		}
+		serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+		if err != nil {
+			anonCertErr = err
+			return
+		}
+		tmpl := &x509.Certificate{
+			SerialNumber: serial,
+			Subject:      pkix.Name{CommonName: "go-vnc"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().AddDate(10, 0, 0),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+		if err != nil {
+			anonCertErr = err
+			return
+		}
+		anonCert = &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	})
+	return anonCert, anonCertErr
+}
+
+func isX509(subtype uint32) bool {
+	return subtype == VeNCryptX509None || subtype == VeNCryptX509Vnc || subtype == VeNCryptX509Plain
+}
+
+func isNoneSubtype(subtype uint32) bool {
+	return subtype == VeNCryptTLSNone || subtype == VeNCryptX509None
+}
+
+func containsSubtype(subtypes []uint32, subtype uint32) bool {
+	for _, st := range subtypes {
+		if st == subtype {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseVeNCryptSubtypes returns the subtypes with the given names, such as
+// X509Plain or TLSVnc.
+func ParseVeNCryptSubtypes(names []string) ([]uint32, error) {
+	out := make([]uint32, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, st := range VeNCryptSubtypes {
+			if strings.EqualFold(subtypeName(st), strings.TrimSpace(name)) {
+				out = append(out, st)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown VeNCrypt subtype %q", name)
+		}
+	}
+	return out, nil
+}
+
+func subtypeName(subtype uint32) string {
+	switch subtype {
+	case VeNCryptTLSNone:
+		return "TLSNone"
+	case VeNCryptTLSVnc:
+		return "TLSVnc"
+	case VeNCryptTLSPlain:
+		return "TLSPlain"
+	case VeNCryptX509None:
+		return "X509None"
+	case VeNCryptX509Vnc:
+		return "X509Vnc"
+	case VeNCryptX509Plain:
+		return "X509Plain"
+	}
+	return fmt.Sprint(subtype)
+}
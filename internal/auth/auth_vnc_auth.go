@@ -3,6 +3,7 @@ package auth
 import (
 	"crypto/des"
 	"crypto/rand"
+	"crypto/subtle"
 	"errors"
 
 	"github.com/suutaku/go-vnc/internal/buffer"
@@ -11,6 +12,11 @@ import (
 // VNCAuth represents VNCAuthentication.
 type VNCAuth struct {
 	Password string
+	// ViewOnlyPassword lets a client watch without controlling anything.
+	ViewOnlyPassword string
+	// Authenticator replaces Password with the passwords of its users, if it
+	// knows them. Nobody but view-only clients can authenticate if it does not.
+	Authenticator Authenticator
 }
 
 // Code returns the code for vnc uth.
@@ -22,67 +28,91 @@ func (a *VNCAuth) Response(rw *buffer.ReadWriter) error {
 	if err := rw.Read(&challenge); err != nil {
 		return err
 	}
-	keyBytes := []byte{0, 0, 0, 0, 0, 0, 0, 0}
-	if len(a.Password) > 8 {
-		a.Password = a.Password[:8]
-	}
-	for i := 0; i < len(a.Password); i++ {
-		keyBytes[i] = a.reverseBits(a.Password[i])
-	}
-	block, err := des.NewCipher(keyBytes)
+	crypted, err := a.encrypt(a.Password, challenge)
 	if err != nil {
 		return err
 	}
-	result1 := make([]byte, 8)
-	block.Encrypt(result1, challenge)
-	result2 := make([]byte, 8)
-	block.Encrypt(result2, challenge[8:])
-	crypted := append(result1, result2...)
-
 	rw.Dispatch(crypted)
 	return nil
 }
 
 // Negotiate auth from server
-func (a *VNCAuth) Negotiate(rw *buffer.ReadWriter) error {
-	key := a.Password
-	keyBytes := []byte{0, 0, 0, 0, 0, 0, 0, 0}
-
-	if len(key) > 8 {
-		key = key[:8]
+func (a *VNCAuth) Negotiate(rw *buffer.ReadWriter) (*Principal, error) {
+	challenge := make([]byte, 16)
+	_, err := rand.Read(challenge)
+	if err != nil {
+		return nil, err
 	}
 
-	for i := 0; i < len(key); i++ {
-		keyBytes[i] = a.reverseBits(key[i])
-	}
+	rw.Dispatch(challenge)
 
-	block, err := des.NewCipher(keyBytes)
-	if err != nil {
-		return err
+	res := make([]byte, 16)
+	if err := rw.Read(res); err != nil {
+		return nil, err
 	}
 
-	challenge := make([]byte, 16)
-	_, err = rand.Read(challenge)
+	candidates, err := a.candidates()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	for _, c := range candidates {
+		expected, err := a.encrypt(c.password, challenge)
+		if err != nil {
+			return nil, err
+		}
+		if subtle.ConstantTimeCompare(res, expected) == 1 {
+			return c.principal, nil
+		}
 	}
+	return nil, errors.New("password is invalid")
+}
 
-	rw.Dispatch(challenge)
+type vncCandidate struct {
+	principal *Principal
+	password  string
+}
 
-	res := make([]byte, 0)
-	for i := 0; i < 2; i++ {
-		b := make([]byte, 8)
-		rw.Read(b)
-		decrypted := make([]byte, 8)
-		block.Decrypt(decrypted, b)
-		res = append(res, decrypted...)
+// candidates returns the passwords a challenge response is checked against, and
+// who gave each of them. Empty passwords are not set and are left out.
+func (a *VNCAuth) candidates() ([]vncCandidate, error) {
+	out := make([]vncCandidate, 0)
+	if a.Authenticator == nil {
+		if a.Password != "" {
+			out = append(out, vncCandidate{&Principal{Permissions: PermAll}, a.Password})
+		}
+	} else if lookup, ok := a.Authenticator.(VNCPasswordLookup); ok {
+		passwords := lookup.VNCPasswords()
+		for _, user := range sortedUsers(passwords) {
+			out = append(out, vncCandidate{&Principal{Username: user, Permissions: PermAll}, passwords[user]})
+		}
 	}
-
-	if string(res) != string(challenge) {
-		return errors.New("password is invalid")
+	if a.ViewOnlyPassword != "" {
+		out = append(out, vncCandidate{&Principal{Permissions: PermViewOnly}, a.ViewOnlyPassword})
 	}
+	if len(out) == 0 {
+		return nil, errors.New("VNCAuth has no password to check")
+	}
+	return out, nil
+}
 
-	return nil
+// encrypt returns the response to a challenge for the given password. Only its
+// first 8 characters are used.
+func (a *VNCAuth) encrypt(password string, challenge []byte) ([]byte, error) {
+	keyBytes := []byte{0, 0, 0, 0, 0, 0, 0, 0}
+	if len(password) > 8 {
+		password = password[:8]
+	}
+	for i := 0; i < len(password); i++ {
+		keyBytes[i] = a.reverseBits(password[i])
+	}
+	block, err := des.NewCipher(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	crypted := make([]byte, 16)
+	block.Encrypt(crypted, challenge)
+	block.Encrypt(crypted[8:], challenge[8:])
+	return crypted, nil
 }
 
 func (a *VNCAuth) reverseBits(b byte) byte {
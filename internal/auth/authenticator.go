@@ -0,0 +1,215 @@
+package auth
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ErrInvalidCredentials is returned by authenticators for a wrong username or
+// password.
+var ErrInvalidCredentials = errors.New("username or password is invalid")
+
+// Authenticator checks the usernames and passwords of clients, for the auth
+// types that ask for them.
+type Authenticator interface {
+	// Authenticate returns nil if password is the password of username, and
+	// ErrInvalidCredentials if it is not.
+	Authenticate(username, password string) error
+}
+
+// VNCPasswordLookup is implemented by authenticators that know the passwords of
+// their users, which VNCAuth needs to check its challenge response. VNCAuth
+// sends no username, so the response is checked against every user's password
+// and only their first 8 characters count.
+type VNCPasswordLookup interface {
+	// VNCPasswords returns the password of each user.
+	VNCPasswords() map[string]string
+}
+
+// StaticAuthenticator is an authenticator with a fixed set of users, kept in
+// memory. It also serves VNCAuth.
+type StaticAuthenticator struct {
+	users map[string]string
+}
+
+// NewStaticAuthenticator returns an authenticator for the given usernames and
+// passwords.
+func NewStaticAuthenticator(users map[string]string) *StaticAuthenticator {
+	a := &StaticAuthenticator{users: make(map[string]string, len(users))}
+	for name, password := range users {
+		a.users[name] = password
+	}
+	return a
+}
+
+// Authenticate checks the password of a user.
+func (a *StaticAuthenticator) Authenticate(username, password string) error {
+	expected, ok := a.users[username]
+	if subtle.ConstantTimeCompare([]byte(password), []byte(expected)) != 1 || !ok {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+// VNCPasswords returns the password of each user.
+func (a *StaticAuthenticator) VNCPasswords() map[string]string {
+	out := make(map[string]string, len(a.users))
+	for name, password := range a.users {
+		out[name] = password
+	}
+	return out
+}
+
+// HtpasswdAuthenticator checks users against an htpasswd file holding bcrypt
+// hashes, as written by `htpasswd -B`. The file is read again when it changes,
+// so users can be added and removed while the server runs. Entries with other
+// hashes are ignored.
+//
+// The passwords are not known, so it cannot serve VNCAuth.
+type HtpasswdAuthenticator struct {
+	path string
+
+	mu      sync.Mutex
+	modTime time.Time
+	size    int64
+	users   map[string][]byte
+	// dummy is compared against for unknown users, with the cost of most
+	// users so they take as long.
+	dummy []byte
+}
+
+// NewHtpasswdAuthenticator returns an authenticator for the users of the given
+// htpasswd file.
+func NewHtpasswdAuthenticator(path string) (*HtpasswdAuthenticator, error) {
+	a := &HtpasswdAuthenticator{path: path}
+	if err := a.reload(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Authenticate checks the password of a user. Unknown users take as long as
+// known ones, so they cannot be told apart by timing.
+func (a *HtpasswdAuthenticator) Authenticate(username, password string) error {
+	if err := a.reload(); err != nil {
+		// Keep the users last read
+		logrus.Error("Reading ", a.path, ": ", err)
+	}
+	a.mu.Lock()
+	hash, ok := a.users[username]
+	dummy := a.dummy
+	a.mu.Unlock()
+	if !ok {
+		hash = dummy
+	}
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil || !ok {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
+
+// newDummyHash returns a bcrypt hash of a random password with the given cost,
+// compared against for unknown users.
+func newDummyHash(cost int) ([]byte, error) {
+	password := make([]byte, 16)
+	if _, err := rand.Read(password); err != nil {
+		return nil, err
+	}
+	return bcrypt.GenerateFromPassword(password, cost)
+}
+
+func dummyCost(hash []byte) int {
+	cost, _ := bcrypt.Cost(hash)
+	return cost
+}
+
+// commonCost returns the cost most of the hashes have, the default cost if
+// there are none.
+func commonCost(users map[string][]byte) int {
+	counts := make(map[int]int)
+	best, bestCount := bcrypt.DefaultCost, 0
+	for _, hash := range users {
+		cost, _ := bcrypt.Cost(hash)
+		counts[cost]++
+		if n := counts[cost]; n > bestCount || n == bestCount && cost > best {
+			best, bestCount = cost, n
+		}
+	}
+	return best
+}
+
+// reload reads the file if it changed since it was last read.
+func (a *HtpasswdAuthenticator) reload() error {
+	info, err := os.Stat(a.path)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.users != nil && info.ModTime().Equal(a.modTime) && info.Size() == a.size {
+		return nil
+	}
+	data, err := ioutil.ReadFile(a.path)
+	if err != nil {
+		return err
+	}
+	users, err := parseHtpasswd(data)
+	if err != nil {
+		return fmt.Errorf("%s: %v", a.path, err)
+	}
+	if cost := commonCost(users); a.dummy == nil || dummyCost(a.dummy) != cost {
+		dummy, err := newDummyHash(cost)
+		if err != nil {
+			return err
+		}
+		a.dummy = dummy
+	}
+	a.users, a.modTime, a.size = users, info.ModTime(), info.Size()
+	logrus.Info("Read ", len(users), " users from ", a.path)
+	return nil
+}
+
+func parseHtpasswd(data []byte) (map[string][]byte, error) {
+	users := make(map[string][]byte)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		i := strings.IndexByte(text, ':')
+		if i <= 0 {
+			return nil, fmt.Errorf("line %d is not username:hash", line)
+		}
+		name, hash := text[:i], text[i+1:]
+		if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+			logrus.Warn("Ignoring user ", name, " on line ", line, ": only bcrypt hashes are supported")
+			continue
+		}
+		users[name] = []byte(hash)
+	}
+	return users, scanner.Err()
+}
+
+// sortedUsers returns the usernames of a password map in order.
+func sortedUsers(passwords map[string]string) []string {
+	names := make([]string, 0, len(passwords))
+	for name := range passwords {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
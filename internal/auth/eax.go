@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"errors"
+)
+
+// eax is the EAX mode of a block cipher with 16 byte blocks, as used by the
+// RSA-AES security types. Nonces and tags are a block long.
+// https://web.cs.ucdavis.edu/~rogaway/papers/eax.pdf
+type eax struct {
+	block  cipher.Block
+	k1, k2 [16]byte
+}
+
+// newEAX returns the EAX mode of the given block cipher.
+func newEAX(block cipher.Block) (cipher.AEAD, error) {
+	if block.BlockSize() != 16 {
+		return nil, errors.New("eax: block size must be 16")
+	}
+	e := &eax{block: block}
+	// CMAC subkeys: doublings of the encrypted zero block
+	var l [16]byte
+	block.Encrypt(l[:], l[:])
+	e.k1 = double(l)
+	e.k2 = double(e.k1)
+	return e, nil
+}
+
+func (e *eax) NonceSize() int { return 16 }
+
+func (e *eax) Overhead() int { return 16 }
+
+func (e *eax) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	n := e.omac(0, nonce)
+	h := e.omac(1, additionalData)
+
+	ret, out := sliceForAppend(dst, len(plaintext)+16)
+	cipher.NewCTR(e.block, n[:]).XORKeyStream(out, plaintext)
+	c := e.omac(2, out[:len(plaintext)])
+	tag := out[len(plaintext):]
+	for i := range tag {
+		tag[i] = n[i] ^ h[i] ^ c[i]
+	}
+	return ret
+}
+
+func (e *eax) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < 16 {
+		return nil, errors.New("eax: message too short")
+	}
+	tag := ciphertext[len(ciphertext)-16:]
+	ciphertext = ciphertext[:len(ciphertext)-16]
+
+	n := e.omac(0, nonce)
+	h := e.omac(1, additionalData)
+	c := e.omac(2, ciphertext)
+	var expected [16]byte
+	for i := range expected {
+		expected[i] = n[i] ^ h[i] ^ c[i]
+	}
+	if subtle.ConstantTimeCompare(expected[:], tag) != 1 {
+		return nil, errors.New("eax: message authentication failed")
+	}
+
+	ret, out := sliceForAppend(dst, len(ciphertext))
+	cipher.NewCTR(e.block, n[:]).XORKeyStream(out, ciphertext)
+	return ret, nil
+}
+
+// omac returns the CMAC of data prefixed with the block holding t.
+func (e *eax) omac(t byte, data []byte) [16]byte {
+	var mac [16]byte
+	mac[15] = t
+	if len(data) == 0 {
+		// The prefix is the last block
+		xorBlock(&mac, e.k1[:])
+		e.block.Encrypt(mac[:], mac[:])
+		return mac
+	}
+	e.block.Encrypt(mac[:], mac[:])
+	for len(data) > 16 {
+		xorBlock(&mac, data[:16])
+		e.block.Encrypt(mac[:], mac[:])
+		data = data[16:]
+	}
+	var last [16]byte
+	copy(last[:], data)
+	if len(data) == 16 {
+		xorBlock(&last, e.k1[:])
+	} else {
+		last[len(data)] = 0x80
+		xorBlock(&last, e.k2[:])
+	}
+	xorBlock(&mac, last[:])
+	e.block.Encrypt(mac[:], mac[:])
+	return mac
+}
+
+// double multiplies a block by x in GF(2^128).
+func double(b [16]byte) [16]byte {
+	var out [16]byte
+	for i := 0; i < 15; i++ {
+		out[i] = b[i]<<1 | b[i+1]>>7
+	}
+	out[15] = b[15] << 1
+	if b[0]&0x80 != 0 {
+		out[15] ^= 0x87
+	}
+	return out
+}
+
+func xorBlock(dst *[16]byte, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
+
+// sliceForAppend extends in by n bytes, returning the whole slice and the
+// extension.
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/aes"
+	"encoding/hex"
+	"testing"
+)
+
+// Test vectors from the EAX paper by Bellare, Rogaway and Wagner.
+var eaxVectors = []struct {
+	msg, key, nonce, header, cipher string
+}{
+	{"", "233952DEE4D5ED5F9B9C6D6FF80FF478", "62EC67F9C3A4A407FCB2A8C49031A8B3", "6BFB914FD07EAE6B", "E037830E8389F27B025A2D6527E79D01"},
+	{"F7FB", "91945D3F4DCBEE0BF45EF52255F095A4", "BECAF043B0A23D843194BA972C66DEBD", "FA3BFD4806EB53FA", "19DD5C4C9331049D0BDAB0277408F67967E5"},
+	{"1A47CB4933", "01F74AD64077F2E704C0F60ADA3DD523", "70C3DB4F0D26368400A10ED05D2BFF5E", "234A3463C1264AC6", "D851D5BAE03A59F238A23E39199DC9266626C40F80"},
+	{"481C9E39B1", "D07CF6CBB7F313BDDE66B727AFD3C5E8", "8408DFFF3C1A2B1292DC199E46B7D617", "33CCE2EABFF5A79D", "632A9D131AD4C168A4225D8E1FF755939974A7BEDE"},
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestEAXVectors(t *testing.T) {
+	for i, v := range eaxVectors {
+		block, err := aes.NewCipher(decodeHex(t, v.key))
+		if err != nil {
+			t.Fatal(err)
+		}
+		aead, err := newEAX(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nonce, header := decodeHex(t, v.nonce), decodeHex(t, v.header)
+		msg, want := decodeHex(t, v.msg), decodeHex(t, v.cipher)
+
+		got := aead.Seal(nil, nonce, msg, header)
+		if !bytes.Equal(got, want) {
+			t.Errorf("vector %d: Seal = %X, want %X", i, got, want)
+		}
+		opened, err := aead.Open(nil, nonce, want, header)
+		if err != nil || !bytes.Equal(opened, msg) {
+			t.Errorf("vector %d: Open = %X, %v, want %X", i, opened, err, msg)
+		}
+		tampered := append([]byte(nil), want...)
+		tampered[len(tampered)-1] ^= 1
+		if _, err := aead.Open(nil, nonce, tampered, header); err == nil {
+			t.Errorf("vector %d: Open accepted a tampered message", i)
+		}
+	}
+}
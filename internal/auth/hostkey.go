@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// DefaultHostKeyBits is the size of the host keys generated.
+const DefaultHostKeyBits = 2048
+
+// LoadHostKey reads the RSA host key of the RSA-AES types from a PEM file. If
+// the file does not exist a new key is generated and saved to it, so clients
+// see the same key after restarts.
+func LoadHostKey(path string) (*rsa.PrivateKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		key, err := rsa.GenerateKey(rand.Reader, DefaultHostKeyBits)
+		if err != nil {
+			return nil, err
+		}
+		block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+		if err := ioutil.WriteFile(path, pem.EncodeToMemory(block), 0600); err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data in host key file %s", path)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("host key is not an RSA key")
+	}
+	return key, nil
+}
+
+// HostKeyFingerprint returns the fingerprint of a host key as TigerVNC's viewer
+// shows it: the first 8 bytes of the SHA-1 of its modulus and exponent.
+func HostKeyFingerprint(key *rsa.PublicKey) string {
+	n, e := marshalPublicKey(key)
+	h := sha1.New()
+	h.Write(n)
+	h.Write(e)
+	sum := h.Sum(nil)
+	parts := make([]string, 8)
+	for i := range parts {
+		parts[i] = fmt.Sprintf("%02x", sum[i])
+	}
+	return strings.Join(parts, "-")
+}
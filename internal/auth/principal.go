@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Permissions are what a client may do besides watching the screen.
+type Permissions uint8
+
+// Permissions of clients.
+const (
+	// PermKeyboard lets the client type.
+	PermKeyboard Permissions = 1 << iota
+	// PermPointer lets the client move the pointer and click.
+	PermPointer
+	// PermClipboardIn lets the client set the host clipboard.
+	PermClipboardIn
+	// PermClipboardOut lets the client see the host clipboard.
+	PermClipboardOut
+	// PermResize lets the client change the framebuffer size.
+	PermResize
+
+	// PermViewOnly only lets the client watch.
+	PermViewOnly Permissions = 0
+	// PermAll gives the client full control.
+	PermAll = PermKeyboard | PermPointer | PermClipboardIn | PermClipboardOut | PermResize
+)
+
+// permissionNames are the names of permissions in configurations.
+var permissionNames = map[string]Permissions{
+	"view-only":     PermViewOnly,
+	"keyboard":      PermKeyboard,
+	"pointer":       PermPointer,
+	"clipboard-in":  PermClipboardIn,
+	"clipboard-out": PermClipboardOut,
+	"resize":        PermResize,
+	"full":          PermAll,
+}
+
+// ParsePermissions returns the permissions with the given names: view-only,
+// keyboard, pointer, clipboard-in, clipboard-out, resize or full.
+func ParsePermissions(names []string) (Permissions, error) {
+	var perms Permissions
+	for _, name := range names {
+		p, ok := permissionNames[strings.TrimSpace(name)]
+		if !ok {
+			return 0, fmt.Errorf("unknown permission %q", name)
+		}
+		perms |= p
+	}
+	return perms, nil
+}
+
+// String returns the names of the permissions.
+func (p Permissions) String() string {
+	switch p {
+	case PermViewOnly:
+		return "view-only"
+	case PermAll:
+		return "full"
+	}
+	names := make([]string, 0)
+	for name, perm := range permissionNames {
+		if perm != PermAll && perm != PermViewOnly && p&perm != 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// Principal is a client that went through authentication.
+type Principal struct {
+	// Username is the name the client logged in with, empty if it gave a
+	// shared password or none.
+	Username    string
+	Permissions Permissions
+}
+
+// Allows returns true if the principal has all of the given permissions.
+func (p *Principal) Allows(perms Permissions) bool { return p.Permissions&perms == perms }
+
+// checkSharedPassword returns the client that gave one of the shared
+// passwords: password gives full control, viewOnly lets it watch. Empty
+// passwords are not set and match nothing.
+func checkSharedPassword(given []byte, password, viewOnly string) (*Principal, error) {
+	if password != "" && subtle.ConstantTimeCompare(given, []byte(password)) == 1 {
+		return &Principal{Permissions: PermAll}, nil
+	}
+	if viewOnly != "" && subtle.ConstantTimeCompare(given, []byte(viewOnly)) == 1 {
+		return &Principal{Permissions: PermViewOnly}, nil
+	}
+	return nil, errors.New("password is invalid")
+}
+
+// authenticate returns the user the authenticator accepts.
+func authenticate(a Authenticator, username, password string) (*Principal, error) {
+	if err := a.Authenticate(username, password); err != nil {
+		return nil, err
+	}
+	return &Principal{Username: username, Permissions: PermAll}, nil
+}
@@ -4,8 +4,10 @@ import (
 	"bufio"
 	"encoding/binary"
 	"errors"
+	"io"
 	"net"
 	"reflect"
+	"sync"
 )
 
 // ReadWriter is a buffer read/writer for RFB conncetions. It is held in a separate
@@ -14,23 +16,32 @@ import (
 // It doesn't implement an actual io.ReadWriter, rather is intended soley for use
 // by the rfb package.
 type ReadWriter struct {
+	c  net.Conn
 	br *bufio.Reader
+
+	// The writer is swapped by Wrap while the queue is empty.
+	mu sync.Mutex
 	bw *bufio.Writer
 
-	wq chan []byte
+	wq      chan []byte
+	pending sync.WaitGroup
 }
 
 // NewReadWriteBuffer returns a new ReadWriter for the given connection.
 func NewReadWriteBuffer(c net.Conn) *ReadWriter {
 	rw := &ReadWriter{
+		c:  c,
 		br: bufio.NewReader(c),
 		bw: bufio.NewWriter(c),
 		wq: make(chan []byte, 100),
 	}
 	go func() {
 		for msg := range rw.wq {
+			rw.mu.Lock()
 			rw.write(msg)
 			rw.flush()
+			rw.mu.Unlock()
+			rw.pending.Done()
 		}
 	}()
 	return rw
@@ -100,5 +111,42 @@ func (rw *ReadWriter) flush() error {
 
 // Dispatch will push packed message(s) onto the buffer queue.
 func (rw *ReadWriter) Dispatch(msg []byte) {
+	rw.pending.Add(1)
 	rw.wq <- msg
 }
+
+// Flush waits until the messages dispatched so far are written.
+func (rw *ReadWriter) Flush() {
+	rw.pending.Wait()
+}
+
+// Wrap layers a new connection over the current one, such as a TLS session,
+// and carries on reading and writing through it. The messages dispatched so far
+// are written out first, and data already buffered for reading is read by the
+// new connection. wrap is given the current connection and may run a handshake
+// over it.
+//
+// Wrap must be called by the goroutine reading the buffer, while nothing else
+// dispatches messages.
+func (rw *ReadWriter) Wrap(wrap func(net.Conn) (net.Conn, error)) error {
+	rw.Flush()
+	c, err := wrap(&bufferedConn{Conn: rw.c, r: rw.br})
+	if err != nil {
+		return err
+	}
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+	rw.c = c
+	rw.br = bufio.NewReader(c)
+	rw.bw = bufio.NewWriter(c)
+	return nil
+}
+
+// bufferedConn is a connection whose reads start with the data buffered from
+// it.
+type bufferedConn struct {
+	net.Conn
+	r io.Reader
+}
+
+func (c *bufferedConn) Read(p []byte) (int, error) { return c.r.Read(p) }
@@ -0,0 +1,164 @@
+package clipboard
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultPollInterval is how often the host clipboard is checked for changes.
+const DefaultPollInterval = 500 * time.Millisecond
+
+// Clipboard is implemented by the host clipboards the server can share with
+// its clients.
+type Clipboard interface {
+	// Read returns the current clipboard contents.
+	Read() (Contents, error)
+	// Write replaces the clipboard contents.
+	Write(c Contents) error
+}
+
+// Memory is a clipboard that only lives in memory, for tests and servers
+// without a desktop clipboard. It holds every format.
+type Memory struct {
+	mu       sync.Mutex
+	contents Contents
+}
+
+// Read returns the current clipboard contents.
+func (m *Memory) Read() (Contents, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.contents.clone(), nil
+}
+
+// Write replaces the clipboard contents.
+func (m *Memory) Write(c Contents) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.contents = c.clone()
+	return nil
+}
+
+// NotifyFunc is called with new clipboard contents. Origin is the client that
+// wrote them, or nil if they changed on the host.
+type NotifyFunc func(c Contents, origin interface{})
+
+// Watcher shares a host clipboard between clients. It polls the clipboard for
+// changes made on the host, and passes on what clients write to it. Clients
+// are never told about their own changes, which would otherwise come back
+// around as a change on the host.
+type Watcher struct {
+	clipboard Clipboard
+	interval  time.Duration
+	notify    NotifyFunc
+
+	mu   sync.Mutex
+	last Contents
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stop      chan struct{}
+}
+
+// NewWatcher returns a watcher for the given clipboard calling notify on
+// changes. It does not poll the clipboard until started.
+func NewWatcher(cb Clipboard, interval time.Duration, notify NotifyFunc) *Watcher {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	return &Watcher{clipboard: cb, interval: interval, notify: notify, stop: make(chan struct{})}
+}
+
+// Start starts polling the clipboard. The current contents are not reported.
+// Calling Start more than once has no effect.
+func (w *Watcher) Start() {
+	w.startOnce.Do(func() {
+		if c, err := w.clipboard.Read(); err == nil {
+			w.last = c
+		}
+		go w.poll()
+	})
+}
+
+// Close stops polling the clipboard.
+func (w *Watcher) Close() {
+	w.stopOnce.Do(func() { close(w.stop) })
+}
+
+// Read returns the current clipboard contents.
+func (w *Watcher) Read() (Contents, error) { return w.clipboard.Read() }
+
+// Write replaces the clipboard contents on behalf of a client, and tells the
+// other clients about it.
+func (w *Watcher) Write(c Contents, origin interface{}) error {
+	w.mu.Lock()
+	if err := w.clipboard.Write(c); err != nil {
+		w.mu.Unlock()
+		return err
+	}
+	// Remember what the host clipboard holds now, which may be less than was
+	// written, so polling does not take it for a change.
+	if now, err := w.clipboard.Read(); err == nil {
+		w.last = now
+	}
+	w.mu.Unlock()
+
+	if w.notify != nil {
+		w.notify(c, origin)
+	}
+	return nil
+}
+
+func (w *Watcher) poll() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+
+		w.mu.Lock()
+		c, err := w.clipboard.Read()
+		if err != nil {
+			w.mu.Unlock()
+			logrus.Debug("Could not read clipboard: ", err)
+			continue
+		}
+		changed := !c.Equal(w.last)
+		if changed {
+			w.last = c
+		}
+		w.mu.Unlock()
+
+		if changed && len(c) > 0 && w.notify != nil {
+			logrus.Debug("Host clipboard changed")
+			w.notify(c, nil)
+		}
+	}
+}
+
+// Equal returns true if both hold the same formats with the same data.
+func (c Contents) Equal(other Contents) bool {
+	if len(c) != len(other) {
+		return false
+	}
+	for f, data := range c {
+		if o, ok := other[f]; !ok || !bytes.Equal(data, o) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Contents) clone() Contents {
+	out := make(Contents, len(c))
+	for f, data := range c {
+		out[f] = append([]byte(nil), data...)
+	}
+	return out
+}
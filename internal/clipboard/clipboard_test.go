@@ -0,0 +1,86 @@
+package clipboard
+
+import (
+	"testing"
+	"time"
+)
+
+type change struct {
+	contents Contents
+	origin   interface{}
+}
+
+func newTestWatcher(t *testing.T) (*Memory, *Watcher, chan change) {
+	t.Helper()
+	mem := &Memory{}
+	changes := make(chan change, 16)
+	w := NewWatcher(mem, 5*time.Millisecond, func(c Contents, origin interface{}) {
+		changes <- change{c, origin}
+	})
+	w.Start()
+	t.Cleanup(w.Close)
+	return mem, w, changes
+}
+
+func waitChange(t *testing.T, changes chan change) change {
+	t.Helper()
+	select {
+	case c := <-changes:
+		return c
+	case <-time.After(time.Second):
+		t.Fatal("no change reported")
+	}
+	return change{}
+}
+
+func TestWatcherHostChange(t *testing.T) {
+	mem, _, changes := newTestWatcher(t)
+	want := Contents{FormatText: []byte("from the host")}
+	mem.Write(want)
+	c := waitChange(t, changes)
+	if c.origin != nil || !c.contents.Equal(want) {
+		t.Errorf("got %q from %v, want %q from the host", c.contents, c.origin, want)
+	}
+	// Unchanged contents are not reported again.
+	time.Sleep(50 * time.Millisecond)
+	if len(changes) != 0 {
+		t.Errorf("unchanged clipboard reported again: %v", <-changes)
+	}
+}
+
+func TestWatcherClientWrite(t *testing.T) {
+	mem, w, changes := newTestWatcher(t)
+	client := "client"
+	want := Contents{FormatText: []byte("from a client"), FormatHTML: []byte("<b>html</b>")}
+	if err := w.Write(want, client); err != nil {
+		t.Fatal(err)
+	}
+	c := waitChange(t, changes)
+	if c.origin != client || !c.contents.Equal(want) {
+		t.Errorf("got %q from %v, want %q from %v", c.contents, c.origin, want, client)
+	}
+	if got, _ := mem.Read(); !got.Equal(want) {
+		t.Errorf("host clipboard holds %q, want %q", got, want)
+	}
+	// Polling must not see the write as a change on the host, which would
+	// send it back to the client it came from.
+	time.Sleep(50 * time.Millisecond)
+	if len(changes) != 0 {
+		t.Errorf("write echoed back: %v", <-changes)
+	}
+}
+
+func TestWatcherStartIgnoresCurrent(t *testing.T) {
+	mem := &Memory{}
+	mem.Write(Contents{FormatText: []byte("before")})
+	changes := make(chan change, 16)
+	w := NewWatcher(mem, 5*time.Millisecond, func(c Contents, origin interface{}) {
+		changes <- change{c, origin}
+	})
+	w.Start()
+	defer w.Close()
+	time.Sleep(50 * time.Millisecond)
+	if len(changes) != 0 {
+		t.Errorf("contents before Start reported: %v", <-changes)
+	}
+}
@@ -0,0 +1,298 @@
+// Package clipboard implements the Extended Clipboard protocol extension, which
+// carries UTF-8 text and other formats in compressed ClientCutText and
+// ServerCutText messages.
+//
+// https://github.com/rfbproto/rfbproto/blob/master/rfbproto.rst#extended-clipboard-pseudo-encoding
+package clipboard
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/suutaku/go-vnc/internal/utils"
+)
+
+// PseudoEncoding is the pseudo-encoding a client lists to announce support
+// for the Extended Clipboard extension, 0xC0A1E5CE as a signed integer.
+const PseudoEncoding int32 = -1063131698
+
+// Clipboard formats, the low 16 bits of the flags.
+const (
+	FormatText  uint32 = 1 << 0
+	FormatRTF   uint32 = 1 << 1
+	FormatHTML  uint32 = 1 << 2
+	FormatDIB   uint32 = 1 << 3
+	FormatFiles uint32 = 1 << 4
+
+	formatMask uint32 = 0xffff
+)
+
+// Clipboard actions, the high 8 bits of the flags.
+const (
+	ActionCaps    uint32 = 1 << 24
+	ActionRequest uint32 = 1 << 25
+	ActionPeek    uint32 = 1 << 26
+	ActionNotify  uint32 = 1 << 27
+	ActionProvide uint32 = 1 << 28
+
+	actionMask uint32 = 0xff << 24
+)
+
+// SupportedFormats are the formats this package can carry.
+const SupportedFormats = FormatText | FormatRTF | FormatHTML
+
+// SupportedActions are the actions this package understands.
+const SupportedActions = ActionRequest | ActionPeek | ActionNotify | ActionProvide
+
+// ErrTooLarge is returned for messages exceeding the size limits.
+var ErrTooLarge = errors.New("clipboard data exceeds the size limit")
+
+// Limits holds the maximum accepted size in bytes per format.
+type Limits map[uint32]uint32
+
+// DefaultLimits are the sizes accepted when nothing else is configured, and
+// the sizes assumed for a peer that did not announce its own.
+var DefaultLimits = Limits{
+	FormatText: 20 << 20,
+	FormatRTF:  10 << 20,
+	FormatHTML: 10 << 20,
+}
+
+// MaxMessageSize returns the largest compressed message worth reading for the
+// given limits.
+func (l Limits) MaxMessageSize() uint32 {
+	var total uint64 = 4 // flags
+	for _, size := range l {
+		total += uint64(size) + 4
+	}
+	if total > 1<<31-1 {
+		return 1<<31 - 1
+	}
+	return uint32(total)
+}
+
+// Formats returns the formats with a non-zero limit.
+func (l Limits) Formats() uint32 {
+	var out uint32
+	for f, size := range l {
+		if size > 0 {
+			out |= f
+		}
+	}
+	return out
+}
+
+// Contents is clipboard data keyed by format. Text formats are held without
+// their NUL terminator, and plain text with LF line endings.
+type Contents map[uint32][]byte
+
+// Formats returns the formats present in the contents.
+func (c Contents) Formats() uint32 {
+	var out uint32
+	for f := range c {
+		out |= f
+	}
+	return out
+}
+
+// Message is a single Extended Clipboard message.
+type Message struct {
+	Flags uint32
+	// Sizes holds the maximum size per format of a caps message.
+	Sizes Limits
+	// Contents holds the data of a provide message.
+	Contents Contents
+}
+
+// NewCaps returns a caps message announcing the supported actions and the
+// given size limits.
+func NewCaps(limits Limits) *Message {
+	return &Message{Flags: ActionCaps | SupportedActions | (limits.Formats() & SupportedFormats), Sizes: limits}
+}
+
+// NewRequest returns a message asking the peer for its clipboard in the given formats.
+func NewRequest(formats uint32) *Message { return &Message{Flags: ActionRequest | formats} }
+
+// NewPeek returns a message asking the peer which formats its clipboard holds.
+func NewPeek() *Message { return &Message{Flags: ActionPeek} }
+
+// NewNotify returns a message telling the peer which formats the clipboard holds.
+func NewNotify(formats uint32) *Message { return &Message{Flags: ActionNotify | formats} }
+
+// NewProvide returns a message carrying the clipboard contents. Formats the
+// peer does not accept, or that exceed its limits, are left out.
+func NewProvide(contents Contents, peer Limits) *Message {
+	out := make(Contents)
+	for f, data := range contents {
+		if uint64(wireSize(f, data)) <= uint64(peer[f]) {
+			out[f] = data
+		}
+	}
+	return &Message{Flags: ActionProvide | out.Formats(), Contents: out}
+}
+
+// Action returns the action of the message. Caps messages also carry the
+// supported actions, so caps takes precedence.
+func (m *Message) Action() uint32 {
+	if m.Flags&ActionCaps != 0 {
+		return ActionCaps
+	}
+	for a := ActionRequest; a <= ActionProvide; a <<= 1 {
+		if m.Flags&a != 0 {
+			return a
+		}
+	}
+	return 0
+}
+
+// Formats returns the formats the message refers to.
+func (m *Message) Formats() uint32 { return m.Flags & formatMask }
+
+// Supports returns true if the flags of a caps message include the action.
+func (m *Message) Supports(action uint32) bool { return m.Flags&action != 0 }
+
+// Parse decodes the payload of a message with the given flags. Data of
+// formats exceeding limits is dropped, and so is everything after it in the
+// same message.
+func Parse(flags uint32, payload []byte, limits Limits) (*Message, error) {
+	m := &Message{Flags: flags}
+	switch m.Action() {
+	case ActionCaps:
+		m.Sizes = make(Limits)
+		r := bytes.NewReader(payload)
+		for _, f := range formatBits(m.Formats()) {
+			var size uint32
+			if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+				return nil, fmt.Errorf("reading clipboard caps: %v", err)
+			}
+			m.Sizes[f] = size
+		}
+	case ActionProvide:
+		contents, err := readContents(m.Formats(), payload, limits)
+		if err != nil && err != ErrTooLarge {
+			return nil, err
+		}
+		m.Contents = contents
+		m.Flags = ActionProvide | contents.Formats()
+		return m, err
+	}
+	return m, nil
+}
+
+// Marshal encodes the flags and payload of the message.
+func (m *Message) Marshal() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	utils.Write(buf, m.Flags)
+	switch m.Action() {
+	case ActionCaps:
+		for _, f := range formatBits(m.Formats()) {
+			utils.Write(buf, m.Sizes[f])
+		}
+	case ActionProvide:
+		zw := zlib.NewWriter(buf)
+		for _, f := range formatBits(m.Formats()) {
+			data := toWire(f, m.Contents[f])
+			if err := binary.Write(zw, binary.BigEndian, uint32(len(data))); err != nil {
+				return nil, err
+			}
+			if _, err := zw.Write(data); err != nil {
+				return nil, err
+			}
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func readContents(formats uint32, payload []byte, limits Limits) (Contents, error) {
+	contents := make(Contents)
+	zr, err := zlib.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("reading clipboard data: %v", err)
+	}
+	defer zr.Close()
+	for _, f := range formatBits(formats) {
+		var size uint32
+		if err := binary.Read(zr, binary.BigEndian, &size); err != nil {
+			return nil, fmt.Errorf("reading clipboard data: %v", err)
+		}
+		if size > limits[f] {
+			return contents, ErrTooLarge
+		}
+		data, err := ioutil.ReadAll(io.LimitReader(zr, int64(size)))
+		if err != nil {
+			return nil, fmt.Errorf("reading clipboard data: %v", err)
+		}
+		if len(data) != int(size) {
+			return nil, fmt.Errorf("reading clipboard data: %v", io.ErrUnexpectedEOF)
+		}
+		if f&SupportedFormats != 0 {
+			contents[f] = fromWire(f, data)
+		}
+	}
+	return contents, nil
+}
+
+// formatBits returns the format flags that are set, in the order their data
+// appears in a message.
+func formatBits(formats uint32) []uint32 {
+	out := make([]uint32, 0)
+	for f := uint32(1); f&formatMask != 0; f <<= 1 {
+		if formats&f != 0 {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// isText returns true for the formats sent as NUL terminated strings.
+func isText(format uint32) bool { return format&(FormatText|FormatRTF|FormatHTML) != 0 }
+
+// toWire converts clipboard data to the form it is sent in. Text uses CRLF line
+// endings, and text formats are NUL terminated.
+func toWire(format uint32, data []byte) []byte {
+	if !isText(format) {
+		return data
+	}
+	if format == FormatText {
+		s := strings.Replace(string(data), "\r\n", "\n", -1)
+		data = []byte(strings.Replace(s, "\n", "\r\n", -1))
+	}
+	// The data may be the caller's, so it is copied rather than appended to.
+	out := make([]byte, len(data)+1)
+	copy(out, data)
+	return out
+}
+
+// fromWire reverses toWire.
+func fromWire(format uint32, data []byte) []byte {
+	if !isText(format) {
+		return data
+	}
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		data = data[:i]
+	}
+	if format == FormatText {
+		data = bytes.Replace(data, []byte("\r\n"), []byte("\n"), -1)
+	}
+	return data
+}
+
+// wireSize returns the size of the data once converted by toWire.
+func wireSize(format uint32, data []byte) int {
+	if !isText(format) {
+		return len(data)
+	}
+	if format == FormatText {
+		return len(toWire(format, data))
+	}
+	return len(data) + 1
+}
@@ -0,0 +1,143 @@
+package clipboard
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"testing"
+)
+
+// roundTrip marshals the message and parses it back with the given limits.
+func roundTrip(t *testing.T, m *Message, limits Limits) (*Message, []byte, error) {
+	t.Helper()
+	b, err := m.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	flags := binary.BigEndian.Uint32(b[:4])
+	if flags != m.Flags {
+		t.Fatalf("flags %#x marshalled as %#x", m.Flags, flags)
+	}
+	parsed, err := Parse(flags, b[4:], limits)
+	return parsed, b[4:], err
+}
+
+// providePayload compresses the data of a provide message.
+func providePayload(t *testing.T, chunks ...[]byte) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	zw := zlib.NewWriter(buf)
+	for _, c := range chunks {
+		binary.Write(zw, binary.BigEndian, uint32(len(c)))
+		zw.Write(c)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestCapsRoundTrip(t *testing.T) {
+	limits := Limits{FormatText: 1 << 20, FormatHTML: 300}
+	m, payload, err := roundTrip(t, NewCaps(limits), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(payload) != 8 {
+		t.Errorf("caps payload is %d bytes, want 8", len(payload))
+	}
+	if m.Action() != ActionCaps {
+		t.Errorf("action %#x, want caps", m.Action())
+	}
+	for _, a := range []uint32{ActionRequest, ActionPeek, ActionNotify, ActionProvide} {
+		if !m.Supports(a) {
+			t.Errorf("caps do not support %#x", a)
+		}
+	}
+	if m.Formats() != FormatText|FormatHTML || m.Sizes[FormatText] != 1<<20 || m.Sizes[FormatHTML] != 300 {
+		t.Errorf("formats %#x with sizes %v, want %v", m.Formats(), m.Sizes, limits)
+	}
+}
+
+func TestProvideRoundTrip(t *testing.T) {
+	contents := Contents{
+		FormatText: []byte("héllo\nworld\r\n世界"),
+		FormatRTF:  []byte("{\\rtf1 x}"),
+		FormatHTML: []byte("<b>x</b>"),
+	}
+	m, payload, err := roundTrip(t, NewProvide(contents, DefaultLimits), DefaultLimits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Action() != ActionProvide || m.Formats() != FormatText|FormatRTF|FormatHTML {
+		t.Errorf("flags %#x, want provide of every format", m.Flags)
+	}
+	want := Contents{
+		FormatText: []byte("héllo\nworld\n世界"),
+		FormatRTF:  []byte("{\\rtf1 x}"),
+		FormatHTML: []byte("<b>x</b>"),
+	}
+	if !m.Contents.Equal(want) {
+		t.Errorf("contents %q, want %q", m.Contents, want)
+	}
+
+	// On the wire text has CRLF line endings and every text format a NUL.
+	zr, err := zlib.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var size uint32
+	binary.Read(zr, binary.BigEndian, &size)
+	text := make([]byte, size)
+	zr.Read(text)
+	if wire := "héllo\r\nworld\r\n世界\x00"; string(text) != wire {
+		t.Errorf("text sent as %q, want %q", text, wire)
+	}
+}
+
+func TestProvideLeavesOutTooLarge(t *testing.T) {
+	contents := Contents{FormatText: []byte("abc"), FormatHTML: []byte("<b>x</b>")}
+	m := NewProvide(contents, Limits{FormatText: 4, FormatHTML: 4})
+	// "abc" takes 4 bytes with its NUL.
+	if m.Formats() != FormatText {
+		t.Errorf("formats %#x, want text only", m.Formats())
+	}
+}
+
+func TestParseNUL(t *testing.T) {
+	payload := providePayload(t, []byte("one\r\ntwo\x00garbage"), []byte("<i>\x00"))
+	m, err := Parse(ActionProvide|FormatText|FormatHTML, payload, DefaultLimits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Contents{FormatText: []byte("one\ntwo"), FormatHTML: []byte("<i>")}
+	if !m.Contents.Equal(want) {
+		t.Errorf("contents %q, want %q", m.Contents, want)
+	}
+}
+
+func TestParseTooLarge(t *testing.T) {
+	payload := providePayload(t, []byte("text\x00"), []byte("{\\rtf1 too large}\x00"), []byte("<b>x</b>\x00"))
+	limits := Limits{FormatText: 100, FormatRTF: 4, FormatHTML: 100}
+	m, err := Parse(ActionProvide|FormatText|FormatRTF|FormatHTML, payload, limits)
+	if err != ErrTooLarge {
+		t.Fatalf("err = %v, want ErrTooLarge", err)
+	}
+	// What came before the format over the limit is kept, the rest dropped.
+	want := Contents{FormatText: []byte("text")}
+	if !m.Contents.Equal(want) || m.Flags != ActionProvide|FormatText {
+		t.Errorf("flags %#x and contents %q, want text only", m.Flags, m.Contents)
+	}
+}
+
+func TestMarshalKeepsContents(t *testing.T) {
+	backing := []byte("<b>x</b>!")
+	html := backing[:len(backing)-1]
+	m := &Message{Flags: ActionProvide | FormatHTML, Contents: Contents{FormatHTML: html}}
+	if _, err := m.Marshal(); err != nil {
+		t.Fatal(err)
+	}
+	if string(backing) != "<b>x</b>!" {
+		t.Errorf("marshalling wrote into the contents: %q", backing)
+	}
+}
@@ -11,29 +11,14 @@ func (d *Display) handleKeyEvents() {
 	for ev := range d.keyEvQueue {
 		logrus.Debug("Got key event: ", ev)
 		if ev.IsDown() {
-			d.appendDownKeyIfMissing(ev.Key)
-			d.dispatchDownKeys()
+			d.pressKey(ev)
 		} else {
-			d.removeDownKey(ev.Key)
+			d.releaseKey(ev)
 		}
 	}
 
-	// for {
-	// 	select {
-	// 	case ev, ok := <-d.keyEvQueue:
-	// 		if !ok {
-	// 			// Client disconnected.
-	// 			return
-	// 		}
-	// 		logrus.Debug("Got key event: ", ev)
-	// 		if ev.IsDown() {
-	// 			d.appendDownKeyIfMissing(ev.Key)
-	// 			d.dispatchDownKeys()
-	// 		} else {
-	// 			d.removeDownKey(ev.Key)
-	// 		}
-	// 	}
-	// }
+	// Client disconnected.
+	d.releaseKeys()
 }
 
 func (d *Display) handlePointerEvents() {
@@ -43,21 +28,13 @@ func (d *Display) handlePointerEvents() {
 		d.servePointerEvent(ev)
 	}
 
-	// for {
-	// 	select {
-	// 	case ev, ok := <-d.ptrEvQueue:
-	// 		if !ok {
-	// 			// Client disconnected.
-	// 			return
-	// 		}
-	// 		logrus.Debug("Got pointer event: ", ev)
-	// 		d.servePointerEvent(ev)
-	// 	}
-	// }
+	// Client disconnected.
+	d.releaseButtons()
 }
 
 func (d *Display) handleFrameBufferEvents() {
-	ticker := time.NewTicker(time.Millisecond * 100)
+	ticker := time.NewTicker(d.framePollInterval())
+	defer ticker.Stop()
 	for {
 		select {
 		// Framebuffer update requests
@@ -67,38 +44,63 @@ func (d *Display) handleFrameBufferEvents() {
 				return
 			}
 			logrus.Debug("Handling framebuffer update request")
-			d.pushFrame(ur)
+			d.queueRequest(ur)
 
-		// Send a frame update anyway if there are no updates on the queue
-		case <-ticker.C:
-			logrus.Debug("Pushing latest frame to client")
-			last := d.GetLastImage()
-			if last == nil {
-				continue
+		// Flow control
+		case req, ok := <-d.fenceQueue:
+			if !ok {
+				return
+			}
+			d.handleFence(req)
+		case req, ok := <-d.cuQueue:
+			if !ok {
+				return
 			}
-			d.pushImage(last)
+			d.setContinuousUpdates(req)
+
+		// Size changes
+		case req, ok := <-d.resizeQueue:
+			if !ok {
+				return
+			}
+			d.handleResize(req)
+		case req, ok := <-d.layoutQueue:
+			if !ok {
+				return
+			}
+			d.handleResize(req)
+
+		// New frames, and retries of updates held back by pacing
+		case <-d.sub.Ready():
+		case <-ticker.C:
 		}
+		d.servePending()
 	}
 }
 
 func (d *Display) handleCutTextEvents() {
-
-	for ev := range d.cutTxtEvsQ {
-		logrus.Debug("Got cut-text event: ", ev)
-		d.syncToClipboard(ev)
+	for {
+		select {
+		case ev, ok := <-d.cutTxtEvsQ:
+			if !ok {
+				// Client disconnected.
+				return
+			}
+			logrus.Debug("Got cut-text event: ", ev)
+			d.syncToClipboard(ev)
+		case m, ok := <-d.clipMsgQueue:
+			if !ok {
+				return
+			}
+			logrus.Debugf("Got extended clipboard message with flags %#x", m.Flags)
+			d.handleClipboardMessage(m)
+		case c, ok := <-d.clipOutQueue:
+			if !ok {
+				return
+			}
+			d.sendClipboard(c)
+		}
 	}
-
-	// for {
-	// 	select {
-	// 	case ev, ok := <-d.cutTxtEvsQ:
-	// 		if !ok {
-	// 			// Client disconnected.
-	// 			return
-	// 		}
-	// 		logrus.Debug("Got cut-text event: ", ev)
-	// 		d.syncToClipboard(ev)
-	// 	}
-	// }
 }
 
 func (d *Display) watchChannels() {
@@ -1,12 +1,23 @@
 package display
 
 import (
-	"github.com/go-vgo/robotgo"
+	"bytes"
+
+	"github.com/sirupsen/logrus"
+	"github.com/suutaku/go-vnc/internal/auth"
+	"github.com/suutaku/go-vnc/internal/clipboard"
 	"github.com/suutaku/go-vnc/internal/types"
+	"github.com/suutaku/go-vnc/internal/utils"
 )
 
-func (d *Display) syncToClipboard(ev *types.ClientCutText) { robotgo.WriteAll(toUTF8(ev.Text)) }
+// Server -> Client
+const cmdServerCutText = 3
+
+func (d *Display) syncToClipboard(ev *types.ClientCutText) {
+	d.writeClipboard(clipboard.Contents{clipboard.FormatText: []byte(toUTF8(ev.Text))})
+}
 
+// toUTF8 converts Latin-1 text, as sent in plain ClientCutText messages.
 func toUTF8(in []byte) string {
 	buf := make([]rune, len(in))
 	for i, b := range in {
@@ -14,3 +25,162 @@ func toUTF8(in []byte) string {
 	}
 	return string(buf)
 }
+
+// toLatin1 converts text for plain ServerCutText messages. Characters outside
+// of Latin-1 are replaced.
+func toLatin1(in string) []byte {
+	out := make([]byte, 0, len(in))
+	for _, r := range in {
+		if r > 0xff {
+			r = '?'
+		}
+		out = append(out, byte(r))
+	}
+	return out
+}
+
+// GetClipboardLimits returns the largest clipboard data accepted per format.
+func (d *Display) GetClipboardLimits() clipboard.Limits { return d.clipLimits }
+
+// DispatchClipboardMessage dispatches an Extended Clipboard message to the queue.
+func (d *Display) DispatchClipboardMessage(m *clipboard.Message) { d.clipMsgQueue <- m }
+
+// SendClipboard queues new host clipboard contents for the client, unless it
+// may not see them. It is safe to call from any goroutine.
+func (d *Display) SendClipboard(c clipboard.Contents) {
+	if !d.Allows(auth.PermClipboardOut) {
+		return
+	}
+	select {
+	case d.clipOutQueue <- c:
+		return
+	default:
+	}
+	// Make room by dropping the oldest contents. The queue can be drained
+	// meanwhile, so neither step may block: the server's connection lock is
+	// held.
+	logrus.Debug("clipboard channel full")
+	select {
+	case <-d.clipOutQueue:
+	default:
+	}
+	select {
+	case d.clipOutQueue <- c:
+	default:
+		logrus.Debug("Dropping clipboard contents, channel full")
+	}
+}
+
+// sendClipboard tells the client about new clipboard contents. Clients with
+// Extended Clipboard support are notified and can request the formats they
+// want, others get the text as Latin-1.
+func (d *Display) sendClipboard(c clipboard.Contents) {
+	if !d.clientSupportsPseudo(clipboard.PseudoEncoding) {
+		text, ok := c[clipboard.FormatText]
+		if !ok {
+			return
+		}
+		latin1 := toLatin1(string(text))
+		buf := new(bytes.Buffer)
+		utils.Write(buf, uint8(cmdServerCutText))
+		utils.Write(buf, []byte{0, 0, 0}) // padding
+		utils.Write(buf, uint32(len(latin1)))
+		utils.Write(buf, latin1)
+		d.buf.Dispatch(buf.Bytes())
+		return
+	}
+
+	caps := d.clientClipCaps
+	formats := c.Formats() & d.clientClipLimits().Formats()
+	switch {
+	case formats == 0:
+	case caps == nil || caps.Supports(clipboard.ActionNotify):
+		d.writeClipboardMessage(clipboard.NewNotify(formats))
+	case caps.Supports(clipboard.ActionProvide):
+		d.writeClipboardMessage(clipboard.NewProvide(c, d.clientClipLimits()))
+	}
+}
+
+// announceClipboard sends the server's clipboard capabilities the first time
+// the client lists the Extended Clipboard pseudo-encoding.
+func (d *Display) announceClipboard() {
+	if !d.announcedClipboard && d.clientSupportsPseudo(clipboard.PseudoEncoding) {
+		d.announcedClipboard = true
+		d.writeClipboardMessage(clipboard.NewCaps(d.clipLimits))
+	}
+}
+
+// handleClipboardMessage acts on an Extended Clipboard message from the client.
+func (d *Display) handleClipboardMessage(m *clipboard.Message) {
+	switch m.Action() {
+	case clipboard.ActionCaps:
+		d.clientClipCaps = m
+	case clipboard.ActionRequest:
+		d.writeClipboardMessage(clipboard.NewProvide(filterContents(d.readClipboard(), m.Formats()), d.clientClipLimits()))
+	case clipboard.ActionPeek:
+		d.writeClipboardMessage(clipboard.NewNotify(d.readClipboard().Formats()))
+	case clipboard.ActionNotify:
+		// Ask for the new clipboard right away, as there is no way to tell
+		// when a local application pastes.
+		if formats := m.Formats() & d.clipLimits.Formats() & clipboard.SupportedFormats; formats != 0 {
+			d.writeClipboardMessage(clipboard.NewRequest(formats))
+		}
+	case clipboard.ActionProvide:
+		d.writeClipboard(m.Contents)
+	}
+}
+
+// clientClipLimits returns the sizes the client accepts per format. Clients
+// that did not send their capabilities are assumed to take the defaults.
+func (d *Display) clientClipLimits() clipboard.Limits {
+	if d.clientClipCaps == nil {
+		return clipboard.DefaultLimits
+	}
+	return d.clientClipCaps.Sizes
+}
+
+func (d *Display) writeClipboardMessage(m *clipboard.Message) {
+	payload, err := m.Marshal()
+	if err != nil {
+		logrus.Error("Could not encode clipboard message: ", err)
+		return
+	}
+	buf := new(bytes.Buffer)
+	utils.Write(buf, uint8(cmdServerCutText))
+	utils.Write(buf, []byte{0, 0, 0}) // padding
+	// A negative length marks an extended message
+	utils.Write(buf, int32(-len(payload)))
+	utils.Write(buf, payload)
+	d.buf.Dispatch(buf.Bytes())
+}
+
+// readClipboard returns the host clipboard contents.
+func (d *Display) readClipboard() clipboard.Contents {
+	c, err := d.clipboard.Read()
+	if err != nil {
+		logrus.Error("Could not read clipboard: ", err)
+		return clipboard.Contents{}
+	}
+	return c
+}
+
+// writeClipboard replaces the host clipboard with contents from the client.
+func (d *Display) writeClipboard(c clipboard.Contents) {
+	if len(c) == 0 {
+		return
+	}
+	if err := d.clipboard.Write(c, d); err != nil {
+		logrus.Error("Could not write clipboard: ", err)
+	}
+}
+
+// filterContents returns the parts of contents in the given formats.
+func filterContents(contents clipboard.Contents, formats uint32) clipboard.Contents {
+	out := make(clipboard.Contents)
+	for f, data := range contents {
+		if f&formats != 0 {
+			out[f] = data
+		}
+	}
+	return out
+}
@@ -0,0 +1,338 @@
+package display
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/maphash"
+	"image"
+	"sort"
+)
+
+// Parameters for detecting content that can be sent with CopyRect.
+const (
+	// Rows whose content appears more often than this in the previous frame,
+	// like blank lines, are too ambiguous to vote for a scroll offset.
+	scrollMaxRowRepeat = 8
+	// Minimum height of a scrolled band worth a CopyRect.
+	scrollMinRows = 16
+
+	moveBlockSize     = 16
+	moveMinVotes      = 4
+	moveMaxCandidates = 4
+	// Block move detection hashes every position of the changed area, so it is
+	// skipped when too much of the screen changed.
+	moveMaxSearchArea = 1 << 20
+
+	// Polynomial bases for the rolling block hash.
+	moveHashRowBase = 1000003
+	moveHashColBase = 999983
+)
+
+// copyRect is content that is already on the client at src and can be copied to dst.
+type copyRect struct {
+	dst image.Rectangle
+	src image.Point
+}
+
+// srcRect returns the source area of the copy.
+func (c copyRect) srcRect() image.Rectangle {
+	return image.Rectangle{Min: c.src, Max: c.src.Add(c.dst.Size())}
+}
+
+// detectCopies compares the frame last sent to the client with the current one
+// and returns the parts of area that moved, either by a vertical scroll or as
+// blocks shifted in any direction. The result is ordered so that no copy reads
+// from an area an earlier copy has written to.
+func detectCopies(prev, cur *image.RGBA, area image.Rectangle) []copyRect {
+	dirty := changedBounds(prev, cur, area)
+	if dirty.Empty() {
+		return nil
+	}
+
+	copies := detectScroll(prev, cur, dirty)
+	remaining := []image.Rectangle{dirty}
+	for _, c := range copies {
+		remaining = subtractRect(remaining, c.dst)
+	}
+	copies = append(copies, detectMoves(prev, cur, remaining)...)
+
+	ordered := make([]copyRect, 0, len(copies))
+	for _, c := range copies {
+		src := c.srcRect()
+		conflict := false
+		for _, o := range ordered {
+			if o.dst.Overlaps(src) {
+				conflict = true
+				break
+			}
+		}
+		if !conflict {
+			ordered = append(ordered, c)
+		}
+	}
+	return ordered
+}
+
+// changedBounds returns the bounding box of the pixels in area that differ
+// between the two frames.
+func changedBounds(prev, cur *image.RGBA, area image.Rectangle) image.Rectangle {
+	var out image.Rectangle
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		a := rowPix(prev, area.Min.X, area.Max.X, y)
+		b := rowPix(cur, area.Min.X, area.Max.X, y)
+		if bytes.Equal(a, b) {
+			continue
+		}
+		first, last := 0, len(a)-1
+		for a[first] == b[first] {
+			first++
+		}
+		for a[last] == b[last] {
+			last--
+		}
+		out = out.Union(image.Rect(area.Min.X+first/4, y, area.Min.X+last/4+1, y+1))
+	}
+	return out
+}
+
+// detectScroll looks for a vertical offset that maps many changed rows of cur
+// onto rows of prev, and returns the bands of rows that moved by it.
+func detectScroll(prev, cur *image.RGBA, dirty image.Rectangle) []copyRect {
+	n := dirty.Dy()
+	if n < scrollMinRows {
+		return nil
+	}
+
+	seed := maphash.MakeSeed()
+	prevHashes := make([]uint64, n)
+	curHashes := make([]uint64, n)
+	rowsByHash := make(map[uint64][]int)
+	for i := 0; i < n; i++ {
+		y := dirty.Min.Y + i
+		prevHashes[i] = hashPix(seed, rowPix(prev, dirty.Min.X, dirty.Max.X, y))
+		curHashes[i] = hashPix(seed, rowPix(cur, dirty.Min.X, dirty.Max.X, y))
+		rowsByHash[prevHashes[i]] = append(rowsByHash[prevHashes[i]], i)
+	}
+
+	votes := make(map[int]int)
+	for i, h := range curHashes {
+		if h == prevHashes[i] {
+			continue
+		}
+		rows := rowsByHash[h]
+		if len(rows) > scrollMaxRowRepeat {
+			continue
+		}
+		for _, j := range rows {
+			votes[i-j]++
+		}
+	}
+	var dy, best int
+	for offset, v := range votes {
+		if v > best || (v == best && abs(offset) < abs(dy)) {
+			dy, best = offset, v
+		}
+	}
+	if best < scrollMinRows {
+		return nil
+	}
+
+	out := make([]copyRect, 0)
+	start := -1
+	for i := 0; i <= n; i++ {
+		j := i - dy
+		match := i < n && j >= 0 && j < n && curHashes[i] == prevHashes[j] &&
+			bytes.Equal(rowPix(cur, dirty.Min.X, dirty.Max.X, dirty.Min.Y+i), rowPix(prev, dirty.Min.X, dirty.Max.X, dirty.Min.Y+j))
+		if match {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 && i-start >= scrollMinRows {
+			dst := image.Rect(dirty.Min.X, dirty.Min.Y+start, dirty.Max.X, dirty.Min.Y+i)
+			out = append(out, copyRect{dst: dst, src: image.Pt(dst.Min.X, dst.Min.Y-dy)})
+		}
+		start = -1
+	}
+	return out
+}
+
+// detectMoves finds blocks of cur inside rects that can be found in prev at a
+// different position. Candidate offsets are voted for by matching rolling
+// hashes of every block-sized window of cur against the aligned blocks of prev,
+// then each aligned block of cur is verified against the best candidates.
+func detectMoves(prev, cur *image.RGBA, rects []image.Rectangle) []copyRect {
+	var area int
+	for _, r := range rects {
+		area += r.Dx() * r.Dy()
+	}
+	if area == 0 || area > moveMaxSearchArea {
+		return nil
+	}
+
+	index := blockIndex(prev)
+	votes := make(map[image.Point]int)
+	for _, r := range rects {
+		rollBlockHashes(cur, r, func(x, y int, h uint64) {
+			if p, ok := index[h]; ok {
+				if v := image.Pt(x-p.X, y-p.Y); v != (image.Point{}) {
+					votes[v]++
+				}
+			}
+		})
+	}
+
+	candidates := make([]image.Point, 0, len(votes))
+	for v, n := range votes {
+		if n >= moveMinVotes {
+			candidates = append(candidates, v)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return votes[candidates[i]] > votes[candidates[j]] })
+	if len(candidates) > moveMaxCandidates {
+		candidates = candidates[:moveMaxCandidates]
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	frame := cur.Bounds()
+	matched := make(map[image.Point][]image.Rectangle)
+	for _, r := range rects {
+		for y := r.Min.Y; y+moveBlockSize <= r.Max.Y; y += moveBlockSize {
+			for x := r.Min.X; x+moveBlockSize <= r.Max.X; x += moveBlockSize {
+				tile := image.Rect(x, y, x+moveBlockSize, y+moveBlockSize)
+				if regionEqual(cur, tile, prev, tile.Min) {
+					continue
+				}
+				for _, v := range candidates {
+					src := tile.Sub(v)
+					if src.In(frame) && regionEqual(cur, tile, prev, src.Min) {
+						matched[v] = append(matched[v], tile)
+						break
+					}
+				}
+			}
+		}
+	}
+
+	out := make([]copyRect, 0)
+	for _, v := range candidates {
+		for _, dst := range mergeRects(matched[v]) {
+			out = append(out, copyRect{dst: dst, src: dst.Min.Sub(v)})
+		}
+	}
+	return out
+}
+
+// blockIndex hashes the aligned blocks of img that are not a single colour.
+func blockIndex(img *image.RGBA) map[uint64]image.Point {
+	b := img.Bounds()
+	index := make(map[uint64]image.Point)
+	for y := b.Min.Y; y+moveBlockSize <= b.Max.Y; y += moveBlockSize {
+		for x := b.Min.X; x+moveBlockSize <= b.Max.X; x += moveBlockSize {
+			var h uint64
+			first := pixelAt(img, x, y)
+			uniform := true
+			for j := 0; j < moveBlockSize; j++ {
+				var row uint64
+				for k := 0; k < moveBlockSize; k++ {
+					p := pixelAt(img, x+k, y+j)
+					uniform = uniform && p == first
+					row = row*moveHashRowBase + uint64(p)
+				}
+				h = h*moveHashColBase + row
+			}
+			if !uniform {
+				if _, ok := index[h]; !ok {
+					index[h] = image.Pt(x, y)
+				}
+			}
+		}
+	}
+	return index
+}
+
+// rollBlockHashes calls fn with the hash of every block-sized window inside r,
+// computing them incrementally so each pixel is only visited once. The hashes
+// match the ones computed by blockIndex.
+func rollBlockHashes(img *image.RGBA, r image.Rectangle, fn func(x, y int, h uint64)) {
+	w := r.Dx() - moveBlockSize + 1
+	if w <= 0 || r.Dy() < moveBlockSize {
+		return
+	}
+	rowPow, colPow := uint64(1), uint64(1)
+	for i := 0; i < moveBlockSize; i++ {
+		rowPow *= moveHashRowBase
+		colPow *= moveHashColBase
+	}
+
+	ring := make([][]uint64, moveBlockSize)
+	for i := range ring {
+		ring[i] = make([]uint64, w)
+	}
+	rowHashes := make([]uint64, w)
+	cols := make([]uint64, w)
+	for j := 0; j < r.Dy(); j++ {
+		y := r.Min.Y + j
+		var h uint64
+		for k := 0; k < moveBlockSize; k++ {
+			h = h*moveHashRowBase + uint64(pixelAt(img, r.Min.X+k, y))
+		}
+		rowHashes[0] = h
+		for x := 1; x < w; x++ {
+			h = h*moveHashRowBase + uint64(pixelAt(img, r.Min.X+x+moveBlockSize-1, y)) -
+				uint64(pixelAt(img, r.Min.X+x-1, y))*rowPow
+			rowHashes[x] = h
+		}
+
+		old := ring[j%moveBlockSize]
+		for x := 0; x < w; x++ {
+			cols[x] = cols[x]*moveHashColBase + rowHashes[x] - old[x]*colPow
+		}
+		copy(old, rowHashes)
+
+		if j >= moveBlockSize-1 {
+			for x := 0; x < w; x++ {
+				fn(r.Min.X+x, y-moveBlockSize+1, cols[x])
+			}
+		}
+	}
+}
+
+// regionEqual reports whether the area r of a holds the same pixels as the
+// same sized area of b starting at bp.
+func regionEqual(a *image.RGBA, r image.Rectangle, b *image.RGBA, bp image.Point) bool {
+	for y := 0; y < r.Dy(); y++ {
+		if !bytes.Equal(rowPix(a, r.Min.X, r.Max.X, r.Min.Y+y), rowPix(b, bp.X, bp.X+r.Dx(), bp.Y+y)) {
+			return false
+		}
+	}
+	return true
+}
+
+// rowPix returns the pixel bytes of row y of img between x0 and x1.
+func rowPix(img *image.RGBA, x0, x1, y int) []byte {
+	o := img.PixOffset(x0, y)
+	return img.Pix[o : o+(x1-x0)*4]
+}
+
+func pixelAt(img *image.RGBA, x, y int) uint32 {
+	o := img.PixOffset(x, y)
+	return binary.LittleEndian.Uint32(img.Pix[o : o+4])
+}
+
+func hashPix(seed maphash.Seed, pix []byte) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	h.Write(pix)
+	return h.Sum64()
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
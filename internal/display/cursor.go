@@ -0,0 +1,234 @@
+package display
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"sync/atomic"
+
+	"github.com/suutaku/go-vnc/internal/encodings"
+	"github.com/suutaku/go-vnc/internal/types"
+	"github.com/suutaku/go-vnc/internal/utils"
+)
+
+// Cursor pseudo-encodings.
+const (
+	pseudoEncodingPointerPos = -232
+	pseudoEncodingRichCursor = -239
+	pseudoEncodingXCursor    = -240
+)
+
+// Cursor is the shape of the pointer. Pixels with an alpha of at least half
+// are part of the cursor, the others are transparent.
+type Cursor struct {
+	Image   *image.RGBA
+	Hotspot image.Point
+}
+
+// CursorProvider is implemented by display providers that know about the
+// pointer. Without it clients keep showing their own cursor.
+type CursorProvider interface {
+	// CursorShape returns the current cursor. The same pointer must be returned
+	// for as long as the shape does not change.
+	CursorShape() *Cursor
+	// CursorPosition returns the position of the pointer in frame coordinates.
+	CursorPosition() image.Point
+}
+
+// updateCursor queues the cursor shape and position for the client if they
+// changed since the last update. Clients that cannot render the cursor
+// themselves get it drawn into img instead, which is returned.
+func (d *Display) updateCursor(img *image.RGBA) *image.RGBA {
+	provider, ok := d.provider().(CursorProvider)
+	if !ok {
+		return img
+	}
+	shape, pos := provider.CursorShape(), provider.CursorPosition()
+	if shape == nil {
+		return img
+	}
+	if atomic.CompareAndSwapInt32(&d.cursorStale, 1, 0) {
+		d.sentCursor = nil
+		d.sentCursorPos = image.Point{-1, -1}
+	}
+
+	rich := d.clientSupportsPseudo(pseudoEncodingRichCursor)
+	if !rich && !d.clientSupportsPseudo(pseudoEncodingXCursor) {
+		r := shape.Image.Bounds().Sub(shape.Image.Bounds().Min).Add(pos.Sub(shape.Hotspot))
+		d.damage = d.damage.Union(d.drawnCursor).Union(r)
+		d.drawnCursor = r
+		return drawCursor(img, shape, pos)
+	}
+	if shape != d.sentCursor {
+		d.sentCursor = shape
+		if rich {
+			d.queuePseudoRect(cursorRect(shape, pseudoEncodingRichCursor), richCursorData(shape, d.GetPixelFormat()))
+		} else {
+			d.queuePseudoRect(cursorRect(shape, pseudoEncodingXCursor), xCursorData(shape))
+		}
+	}
+	if !pos.In(img.Bounds()) {
+		pos = image.Pt(clamp(pos.X, img.Bounds().Min.X, img.Bounds().Max.X-1), clamp(pos.Y, img.Bounds().Min.Y, img.Bounds().Max.Y-1))
+	}
+	if pos != d.sentCursorPos && d.clientSupportsPseudo(pseudoEncodingPointerPos) {
+		d.sentCursorPos = pos
+		d.queuePseudoRect(&types.FrameBufferRectangle{
+			X: uint16(pos.X), Y: uint16(pos.Y), EncType: pseudoEncodingPointerPos,
+		}, nil)
+	}
+	return img
+}
+
+// resetCursor makes the next update carry the cursor again. It is called when
+// the client changes its encodings or pixel format, from any goroutine.
+func (d *Display) resetCursor() { atomic.StoreInt32(&d.cursorStale, 1) }
+
+func cursorRect(c *Cursor, enc int32) *types.FrameBufferRectangle {
+	b := c.Image.Bounds()
+	return &types.FrameBufferRectangle{
+		X: uint16(c.Hotspot.X), Y: uint16(c.Hotspot.Y), Width: uint16(b.Dx()), Height: uint16(b.Dy()), EncType: enc,
+	}
+}
+
+// richCursorData returns the cursor pixels in the client's pixel format
+// followed by the transparency bitmask.
+func richCursorData(c *Cursor, f *types.PixelFormat) []byte {
+	buf := new(bytes.Buffer)
+	(&encodings.RawEncoding{}).HandleBuffer(buf, f, unpremultiplied(c.Image))
+	buf.Write(cursorBitmap(c.Image, func(p color.RGBA) bool { return p.A >= 0x80 }))
+	return buf.Bytes()
+}
+
+// xCursorData returns the two colour form of the cursor. Dark pixels use the
+// primary colour and light ones the secondary colour.
+func xCursorData(c *Cursor) []byte {
+	visible := func(p color.RGBA) bool { return p.A >= 0x80 }
+	dark := func(p color.RGBA) bool { return visible(p) && luma(p) < 0x80 }
+
+	b := c.Image.Bounds()
+	if b.Empty() {
+		return nil
+	}
+
+	var fg, bg [3]int
+	var nfg, nbg int
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			p := c.Image.RGBAAt(x, y)
+			switch {
+			case dark(p):
+				fg[0], fg[1], fg[2], nfg = fg[0]+int(p.R), fg[1]+int(p.G), fg[2]+int(p.B), nfg+1
+			case visible(p):
+				bg[0], bg[1], bg[2], nbg = bg[0]+int(p.R), bg[1]+int(p.G), bg[2]+int(p.B), nbg+1
+			}
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	utils.Write(buf, averageColour(fg, nfg, 0x00))
+	utils.Write(buf, averageColour(bg, nbg, 0xff))
+	buf.Write(cursorBitmap(c.Image, dark))
+	buf.Write(cursorBitmap(c.Image, visible))
+	return buf.Bytes()
+}
+
+// cursorBitmap packs one bit per pixel, most significant bit first, with each
+// row padded to a whole byte.
+func cursorBitmap(img *image.RGBA, set func(color.RGBA) bool) []byte {
+	b := img.Bounds()
+	stride := (b.Dx() + 7) / 8
+	out := make([]byte, stride*b.Dy())
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			if set(img.RGBAAt(b.Min.X+x, b.Min.Y+y)) {
+				out[y*stride+x/8] |= 0x80 >> uint(x%8)
+			}
+		}
+	}
+	return out
+}
+
+// drawCursor returns a copy of img with the cursor drawn at pos.
+func drawCursor(img *image.RGBA, c *Cursor, pos image.Point) *image.RGBA {
+	out := image.NewRGBA(img.Bounds())
+	copy(out.Pix, img.Pix)
+	r := c.Image.Bounds().Sub(c.Image.Bounds().Min).Add(pos.Sub(c.Hotspot))
+	draw.Draw(out, r, c.Image, c.Image.Bounds().Min, draw.Over)
+	return out
+}
+
+// unpremultiplied returns the colours of img without the alpha premultiplied.
+func unpremultiplied(img *image.RGBA) *image.RGBA {
+	out := image.NewRGBA(img.Bounds())
+	copy(out.Pix, img.Pix)
+	for i := 0; i < len(out.Pix); i += 4 {
+		if a := int(out.Pix[i+3]); a > 0 && a < 0xff {
+			for c := 0; c < 3; c++ {
+				out.Pix[i+c] = uint8(int(out.Pix[i+c]) * 0xff / a)
+			}
+		}
+	}
+	return out
+}
+
+func clamp(v, lo, hi int) int {
+	switch {
+	case v < lo:
+		return lo
+	case v > hi:
+		return hi
+	}
+	return v
+}
+
+func luma(p color.RGBA) int {
+	return (299*int(p.R) + 587*int(p.G) + 114*int(p.B)) / 1000
+}
+
+func averageColour(sum [3]int, n int, fallback uint8) []byte {
+	if n == 0 {
+		return []byte{fallback, fallback, fallback}
+	}
+	return []byte{uint8(sum[0] / n), uint8(sum[1] / n), uint8(sum[2] / n)}
+}
+
+// arrowCursor is a plain arrow for providers that cannot read the cursor shape.
+var arrowCursor = newCursor([]string{
+	"X           ",
+	"XX          ",
+	"X.X         ",
+	"X..X        ",
+	"X...X       ",
+	"X....X      ",
+	"X.....X     ",
+	"X......X    ",
+	"X.......X   ",
+	"X........X  ",
+	"X.........X ",
+	"X......XXXXX",
+	"X...X..X    ",
+	"X..XX..X    ",
+	"X.X  X..X   ",
+	"XX   X..X   ",
+	"X     X..X  ",
+	"      X..X  ",
+	"       XX   ",
+}, image.Point{})
+
+// newCursor builds a cursor from rows of text, where X is black, a dot is
+// white and anything else is transparent.
+func newCursor(rows []string, hotspot image.Point) *Cursor {
+	img := image.NewRGBA(image.Rect(0, 0, len(rows[0]), len(rows)))
+	for y, row := range rows {
+		for x, c := range row {
+			switch c {
+			case 'X':
+				img.SetRGBA(x, y, color.RGBA{0, 0, 0, 0xff})
+			case '.':
+				img.SetRGBA(x, y, color.RGBA{0xff, 0xff, 0xff, 0xff})
+			}
+		}
+	}
+	return &Cursor{Image: img, Hotspot: hotspot}
+}
@@ -0,0 +1,69 @@
+package display
+
+import (
+	"bytes"
+	"image"
+)
+
+const (
+	// Size of the tiles compared when looking for damage.
+	damageTileSize = 64
+	// Updates with more rectangles than this are coalesced further, as every
+	// rectangle costs a header and encoder setup.
+	damageMaxRects = 64
+)
+
+// damagedRects compares the frame last sent to the client with the current one
+// and returns the tiles of area that changed, merged into as few rectangles as
+// practical. It returns nil when nothing changed.
+func damagedRects(prev, cur *image.RGBA, area image.Rectangle) []image.Rectangle {
+	b := cur.Bounds()
+	tiles := make([]image.Rectangle, 0)
+	for ty := b.Min.Y; ty < b.Max.Y; ty += damageTileSize {
+		for tx := b.Min.X; tx < b.Max.X; tx += damageTileSize {
+			tile := image.Rect(tx, ty, tx+damageTileSize, ty+damageTileSize).Intersect(area)
+			if tile.Empty() {
+				continue
+			}
+			for y := tile.Min.Y; y < tile.Max.Y; y++ {
+				if !bytes.Equal(rowPix(prev, tile.Min.X, tile.Max.X, y), rowPix(cur, tile.Min.X, tile.Max.X, y)) {
+					tiles = append(tiles, tile)
+					break
+				}
+			}
+		}
+	}
+	return coalesceRects(mergeRects(tiles))
+}
+
+// coalesceRects reduces rects to at most damageMaxRects, first by joining
+// everything on the same rows into a single span, then into a bounding box.
+func coalesceRects(rects []image.Rectangle) []image.Rectangle {
+	if len(rects) <= damageMaxRects {
+		return rects
+	}
+	rows := make(map[[2]int]image.Rectangle)
+	order := make([][2]int, 0)
+	for _, r := range rects {
+		key := [2]int{r.Min.Y, r.Max.Y}
+		if existing, ok := rows[key]; ok {
+			rows[key] = existing.Union(r)
+			continue
+		}
+		rows[key] = r
+		order = append(order, key)
+	}
+	spans := make([]image.Rectangle, 0, len(order))
+	for _, key := range order {
+		spans = append(spans, rows[key])
+	}
+	spans = mergeRects(spans)
+	if len(spans) <= damageMaxRects {
+		return spans
+	}
+	var bounds image.Rectangle
+	for _, r := range spans {
+		bounds = bounds.Union(r)
+	}
+	return []image.Rectangle{bounds}
+}
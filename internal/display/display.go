@@ -2,37 +2,106 @@ package display
 
 import (
 	"image"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/suutaku/go-vnc/internal/auth"
 	"github.com/suutaku/go-vnc/internal/buffer"
+	"github.com/suutaku/go-vnc/internal/clipboard"
 	"github.com/suutaku/go-vnc/internal/encodings"
+	"github.com/suutaku/go-vnc/internal/input"
 	"github.com/suutaku/go-vnc/internal/types"
 )
 
 // Display represents a session with the local display. It manages the gstreamer pipelines
 // and listens for events from the RFB event handlers.
 type Display struct {
-	displayProvider IDisplay
+	// Frames shared with the other connections, and the newest one taken.
+	pipeline *Pipeline
+	sub      *Subscription
+	frame    *Frame
 
 	width, height    int
 	pixelFormat      *types.PixelFormat
 	getEncodingsFunc GetEncodingsFunc
 	encodings        []int32
 	pseudoEncodings  []int32
+	tuning           *encodings.Tuning
 	currentEnc       encodings.Encoding
+	encoders         map[int32]encodings.Encoding // per-connection instances of stateful encodings
 
-	// Read/writer for the connected client
-	buf *buffer.ReadWriter
+	// Last frame sent to the client, used to find damage and content that can
+	// be copied.
+	lastSent *image.RGBA
+
+	// Area changed since the last update, for providers reporting damage, and
+	// where the cursor was last drawn into the frame.
+	damage      image.Rectangle
+	drawnCursor image.Rectangle
+
+	// Update request the client is waiting on, and pacing of the updates.
+	pending           *updateRequest
+	lastUpdate        time.Time
+	minUpdateInterval time.Duration
+
+	// Continuous updates requested by the client.
+	continuous          bool
+	continuousArea      image.Rectangle
+	announcedContinuous bool
+
+	// Fences sent after updates by sequence number, and the round trip time
+	// they measured.
+	announcedFence bool
+	fenceSeq       uint32
+	fencesInFlight map[uint32]time.Time
+	rtt            int64 // time.Duration, accessed atomically
+
+	// Screen layout of the framebuffer, and resizes the client has to hear about.
+	screens              []types.Screen
+	resizedAt            time.Time
+	needFullUpdate       bool
+	announcedDesktopSize bool
+	onResize             ResizeFunc
+
+	// Cursor shape and position the client was last sent.
+	sentCursor    *Cursor
+	sentCursorPos image.Point
+	cursorStale   int32 // set when the cursor has to be sent again, accessed atomically
+
+	// Host clipboard, and the Extended Clipboard limits and capabilities.
+	clipboard          *clipboard.Watcher
+	clipLimits         clipboard.Limits
+	clientClipCaps     *clipboard.Message
+	announcedClipboard bool
+
+	// Pseudo-encoding rectangles to send with the next update.
+	pseudoMu    sync.Mutex
+	pseudoRects [][]byte
+
+	// Read/writer for the connected client, and what it may do.
+	buf       *buffer.ReadWriter
+	principal *auth.Principal
 
 	// Incoming event queues
-	fbReqQueue chan *types.FrameBufferUpdateRequest
-	ptrEvQueue chan *types.PointerEvent
-	keyEvQueue chan *types.KeyEvent
-	cutTxtEvsQ chan *types.ClientCutText
-
-	// Memory of keys that are currently down. Reiterated in order
-	// on every down subsequent down event.
-	downKeys []uint32
+	fbReqQueue   chan *types.FrameBufferUpdateRequest
+	ptrEvQueue   chan *types.PointerEvent
+	keyEvQueue   chan *types.KeyEvent
+	cutTxtEvsQ   chan *types.ClientCutText
+	fenceQueue   chan *fenceRequest
+	cuQueue      chan *types.EnableContinuousUpdates
+	resizeQueue  chan *resizeRequest
+	layoutQueue  chan *resizeRequest
+	clipMsgQueue chan *clipboard.Message
+	clipOutQueue chan clipboard.Contents
+
+	// Where the client's input goes. Keys that are currently held down,
+	// in the order they were pressed, and buttons are released when the
+	// client disconnects.
+	input                 input.Sink
+	downKeys              []*types.KeyEvent
+	buttonMask            uint8
+	announcedExtendedKeys bool
 }
 
 // DefaultPixelFormat is the default pixel format used in ServerInit messages.
@@ -59,25 +128,80 @@ type Opts struct {
 	Width, Height   int
 	Buffer          *buffer.ReadWriter
 	GetEncodingFunc GetEncodingsFunc
+	// MaxFPS caps the rate of incremental updates, zero means no cap.
+	MaxFPS int
+	// FrameRate is the rate of providers generating frames, zero for their
+	// default.
+	FrameRate int
+	// Pipeline provides the frames, shared with the other connections of a
+	// server. If nil the display runs DisplayProvider on its own.
+	Pipeline *Pipeline
+	// OnResize is called when the client changed the framebuffer size.
+	OnResize ResizeFunc
+	// ClipboardLimits are the largest clipboard data accepted per format,
+	// clipboard.DefaultLimits if nil.
+	ClipboardLimits clipboard.Limits
+	// Clipboard is the host clipboard shared with the client. If nil the
+	// clipboard of the input sink is used without watching it for changes.
+	Clipboard *clipboard.Watcher
+	// InputSink receives the client's input, the desktop if nil.
+	InputSink input.Sink
 }
 
 // NewDisplay returns a new display with the given dimensions. These
 // dimensions can be mutated later on depending on client support.
 func NewDisplay(opts *Opts) *Display {
+	var minUpdateInterval time.Duration
+	if opts.MaxFPS > 0 {
+		minUpdateInterval = time.Second / time.Duration(opts.MaxFPS)
+	}
+	clipLimits := opts.ClipboardLimits
+	if clipLimits == nil {
+		clipLimits = clipboard.DefaultLimits
+	}
+	sink := opts.InputSink
+	if sink == nil {
+		sink = &input.Robotgo{}
+	}
+	clip := opts.Clipboard
+	if clip == nil {
+		clip = clipboard.NewWatcher(sink.Clipboard(), 0, nil)
+	}
+	pipeline := opts.Pipeline
+	if pipeline == nil {
+		pipeline = NewPipeline(opts.DisplayProvider, opts.FrameRate)
+	}
 	return &Display{
-		displayProvider:  GetDisplayProvider(opts.DisplayProvider),
-		width:            opts.Width,
-		height:           opts.Height,
-		buf:              opts.Buffer,
-		getEncodingsFunc: opts.GetEncodingFunc,
-		pixelFormat:      DefaultPixelFormat,
+		pipeline:          pipeline,
+		width:             opts.Width,
+		height:            opts.Height,
+		buf:               opts.Buffer,
+		principal:         &auth.Principal{Permissions: auth.PermAll},
+		getEncodingsFunc:  opts.GetEncodingFunc,
+		pixelFormat:       DefaultPixelFormat,
+		encoders:          make(map[int32]encodings.Encoding),
+		minUpdateInterval: minUpdateInterval,
+		screens:           []types.Screen{{Width: uint16(opts.Width), Height: uint16(opts.Height)}},
+		onResize:          opts.OnResize,
+		sentCursorPos:     image.Point{-1, -1},
+		clipLimits:        clipLimits,
+		clipboard:         clip,
+		input:             sink,
 		// Buffered channels
-		fbReqQueue: make(chan *types.FrameBufferUpdateRequest, 128),
-		ptrEvQueue: make(chan *types.PointerEvent, 128),
-		keyEvQueue: make(chan *types.KeyEvent, 128),
-		cutTxtEvsQ: make(chan *types.ClientCutText, 128),
+		fbReqQueue:   make(chan *types.FrameBufferUpdateRequest, 128),
+		ptrEvQueue:   make(chan *types.PointerEvent, 128),
+		keyEvQueue:   make(chan *types.KeyEvent, 128),
+		cutTxtEvsQ:   make(chan *types.ClientCutText, 128),
+		fenceQueue:   make(chan *fenceRequest, 128),
+		cuQueue:      make(chan *types.EnableContinuousUpdates, 128),
+		resizeQueue:  make(chan *resizeRequest, 128),
+		layoutQueue:  make(chan *resizeRequest, 1),
+		clipMsgQueue: make(chan *clipboard.Message, 128),
+		clipOutQueue: make(chan clipboard.Contents, 8),
+		// fences sent to the client
+		fencesInFlight: make(map[uint32]time.Time),
 		// down key memory
-		downKeys: make([]uint32, 0),
+		downKeys: make([]*types.KeyEvent, 0),
 	}
 }
 
@@ -90,11 +214,24 @@ func (d *Display) SetDimensions(width, height int) {
 	d.height = height
 }
 
+// Principal returns the authenticated client of the display.
+func (d *Display) Principal() *auth.Principal { return d.principal }
+
+// SetPrincipal sets the authenticated client of the display, which has full
+// control until then. It must be called before Start.
+func (d *Display) SetPrincipal(p *auth.Principal) { d.principal = p }
+
+// Allows returns true if the client has all of the given permissions.
+func (d *Display) Allows(perms auth.Permissions) bool { return d.principal.Allows(perms) }
+
 // GetPixelFormat returns the current pixel format for the display.
 func (d *Display) GetPixelFormat() *types.PixelFormat { return d.pixelFormat }
 
 // SetPixelFormat sets the pixel format for the display.
-func (d *Display) SetPixelFormat(pf *types.PixelFormat) { d.pixelFormat = pf }
+func (d *Display) SetPixelFormat(pf *types.PixelFormat) {
+	d.pixelFormat = pf
+	d.resetCursor()
+}
 
 // GetEncodings returns the encodings currently supported by the client
 // connected to this display.
@@ -105,14 +242,59 @@ func (d *Display) SetEncodings(encs []int32, pseudoEns []int32) {
 	logrus.Info("set encodings", encs)
 	d.encodings = encs
 	d.pseudoEncodings = pseudoEns
-	d.currentEnc = d.getEncodingsFunc(encs)
+	d.tuning = encodings.ParseTuning(pseudoEns)
+	d.currentEnc = d.encoderInstance(d.getEncodingsFunc(encs))
+	if tunable, ok := d.currentEnc.(encodings.Tunable); ok {
+		tunable.Tune(d.tuning)
+	}
+	d.announceFlowControl()
+	d.announceDesktopSize()
+	d.announceClipboard()
+	d.announceExtendedKeys()
+	d.resetCursor()
+}
+
+// encoderInstance returns the encoder this display should use for the given
+// server-wide encoding. Stateful encodings are instantiated once per display
+// and reused, so their streams survive further SetEncodings messages.
+func (d *Display) encoderInstance(enc encodings.Encoding) encodings.Encoding {
+	stateful, ok := enc.(encodings.StatefulEncoding)
+	if !ok {
+		return enc
+	}
+	if inst, ok := d.encoders[enc.Code()]; ok {
+		return inst
+	}
+	inst := stateful.NewInstance()
+	d.encoders[enc.Code()] = inst
+	return inst
 }
 
 // GetCurrentEncoding returns the encoder that is currently being used.
 func (d *Display) GetCurrentEncoding() encodings.Encoding { return d.currentEnc }
 
-// GetLastImage returns the most recent frame for the display.
-func (d *Display) GetLastImage() *image.RGBA { return d.displayProvider.PullFrame() }
+// GetLastImage returns the most recent frame taken for the display, or nil if
+// there is none yet.
+func (d *Display) GetLastImage() *image.RGBA {
+	if d.frame == nil {
+		return nil
+	}
+	return d.frame.Image
+}
+
+// nextFrame takes the newest frame from the pipeline, if there is a new one,
+// and returns the most recent frame. The damage of new frames is added to what
+// the client has not been sent yet.
+func (d *Display) nextFrame() *image.RGBA {
+	if f := d.sub.Next(); f != nil {
+		d.frame = f
+		d.damage = d.damage.Union(f.Damage)
+	}
+	return d.GetLastImage()
+}
+
+// provider returns the display provider, nil if it is not running.
+func (d *Display) provider() IDisplay { return d.pipeline.Provider() }
 
 // DispatchFrameBufferUpdate dispatches a FrameBufferUpdateRequest on the request queue.
 func (d *Display) DispatchFrameBufferUpdate(req *types.FrameBufferUpdateRequest) {
@@ -142,9 +324,11 @@ func (d *Display) DispatchClientCutText(ev *types.ClientCutText) { d.cutTxtEvsQ
 
 // Start will start the underlying display provider.
 func (d *Display) Start() error {
-	if err := d.displayProvider.Start(d.GetDimensions()); err != nil {
+	sub, err := d.pipeline.Subscribe(d.GetDimensions())
+	if err != nil {
 		return err
 	}
+	d.sub = sub
 	go d.watchChannels()
 	return nil
 }
@@ -155,5 +339,14 @@ func (d *Display) Close() error {
 	close(d.ptrEvQueue)
 	close(d.keyEvQueue)
 	close(d.cutTxtEvsQ)
-	return d.displayProvider.Close()
+	close(d.fenceQueue)
+	close(d.cuQueue)
+	close(d.resizeQueue)
+	close(d.layoutQueue)
+	close(d.clipMsgQueue)
+	close(d.clipOutQueue)
+	if d.sub == nil {
+		return nil
+	}
+	return d.sub.Close()
 }
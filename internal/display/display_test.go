@@ -0,0 +1,32 @@
+package display
+
+import (
+	"testing"
+	"time"
+
+	"github.com/suutaku/go-vnc/internal/input"
+)
+
+// startTestDisplay starts a display on the test pattern whose input goes to
+// the recorder.
+func startTestDisplay(t *testing.T, rec *input.Recorder) *Display {
+	t.Helper()
+	d := NewDisplay(&Opts{DisplayProvider: ProviderTestPattern, Width: 64, Height: 48, InputSink: rec})
+	if err := d.Start(); err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+// waitEvents waits for the recorder to have n events and returns them.
+func waitEvents(t *testing.T, rec *input.Recorder, n int) []input.Event {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		events := rec.Events()
+		if len(events) >= n || time.Now().After(deadline) {
+			return events
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,195 @@
+package display
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/suutaku/go-vnc/internal/types"
+	"github.com/suutaku/go-vnc/internal/utils"
+)
+
+// Pseudo-encodings a client lists to announce support for flow control.
+const (
+	pseudoEncodingFence             = -312
+	pseudoEncodingContinuousUpdates = -313
+)
+
+// Server -> Client
+const (
+	cmdEndOfContinuousUpdates = 150
+	cmdFence                  = 248
+)
+
+const (
+	// Continuous updates are held while this many updates are waiting for the
+	// fence sent after them to come back.
+	maxUpdatesInFlight = 2
+	// Fences that did not come back after this long are forgotten, so a client
+	// that never answers does not stall its updates.
+	fenceTimeout = 5 * time.Second
+)
+
+// fenceRequest is a fence from the client waiting to be handled by the
+// framebuffer update loop.
+type fenceRequest struct {
+	fence *types.Fence
+	done  chan struct{}
+}
+
+// DispatchFence dispatches a fence from the client. Fences are handled in order
+// with the framebuffer update requests, so a response goes out after the updates
+// for the requests preceding it. The returned channel is closed once the fence
+// is handled.
+func (d *Display) DispatchFence(f *types.Fence) <-chan struct{} {
+	req := &fenceRequest{fence: f, done: make(chan struct{})}
+	d.fenceQueue <- req
+	return req.done
+}
+
+// DispatchContinuousUpdates dispatches a request to enable or disable continuous
+// updates.
+func (d *Display) DispatchContinuousUpdates(req *types.EnableContinuousUpdates) {
+	d.cuQueue <- req
+}
+
+// RTT returns the round trip time to the client, measured with the fences sent
+// after each update. It is zero until the first fence comes back, or if the
+// client does not support fences.
+func (d *Display) RTT() time.Duration { return time.Duration(atomic.LoadInt64(&d.rtt)) }
+
+// announceFlowControl tells the client that the server supports the flow
+// control extensions the first time it lists them. Continuous updates are
+// announced with an EndOfContinuousUpdates message, fences with a fence.
+func (d *Display) announceFlowControl() {
+	if !d.announcedContinuous && d.clientSupportsPseudo(pseudoEncodingContinuousUpdates) {
+		d.announcedContinuous = true
+		d.buf.Dispatch([]byte{cmdEndOfContinuousUpdates})
+	}
+	if !d.announcedFence && d.clientSupportsPseudo(pseudoEncodingFence) {
+		d.announcedFence = true
+		d.writeFence(types.FenceRequest, nil)
+	}
+}
+
+// setContinuousUpdates turns continuous updates for the given area on or off.
+func (d *Display) setContinuousUpdates(req *types.EnableContinuousUpdates) {
+	if req.Enabled() {
+		logrus.Debug("Enabling continuous updates")
+		d.continuous = true
+		d.continuousArea = image.Rect(int(req.X), int(req.Y), int(req.X)+int(req.Width), int(req.Y)+int(req.Height))
+		return
+	}
+	logrus.Debug("Disabling continuous updates")
+	d.continuous = false
+	d.buf.Dispatch([]byte{cmdEndOfContinuousUpdates})
+}
+
+// handleFence answers a fence request from the client, or takes the round trip
+// time from a fence the server sent.
+func (d *Display) handleFence(req *fenceRequest) {
+	defer close(req.done)
+	f := req.fence
+	if !f.IsRequest() {
+		d.fenceReturned(f.Payload)
+		return
+	}
+	// Update requests sent before the fence may still be queued, they have to
+	// be handled before the response goes out.
+	d.drainRequests()
+	d.servePending()
+	d.writeFence(f.Flags&types.FenceSupportedFlags, f.Payload)
+}
+
+// drainRequests queues the update requests waiting on the channel.
+func (d *Display) drainRequests() {
+	for {
+		select {
+		case ur, ok := <-d.fbReqQueue:
+			if !ok {
+				return
+			}
+			d.queueRequest(ur)
+		default:
+			return
+		}
+	}
+}
+
+// sendUpdateFence follows an update with a fence, which the client returns once
+// it has processed the update.
+func (d *Display) sendUpdateFence() {
+	if !d.clientSupportsPseudo(pseudoEncodingFence) {
+		return
+	}
+	d.expireFences()
+	d.fenceSeq++
+	d.fencesInFlight[d.fenceSeq] = time.Now()
+	payload := make([]byte, 4)
+	binary.BigEndian.PutUint32(payload, d.fenceSeq)
+	d.writeFence(types.FenceRequest|types.FenceBlockBefore, payload)
+}
+
+// fenceReturned updates the round trip time with a fence that came back.
+func (d *Display) fenceReturned(payload []byte) {
+	if len(payload) != 4 {
+		return
+	}
+	seq := binary.BigEndian.Uint32(payload)
+	sent, ok := d.fencesInFlight[seq]
+	if !ok {
+		return
+	}
+	delete(d.fencesInFlight, seq)
+
+	sample := time.Since(sent)
+	rtt := d.RTT()
+	if rtt == 0 {
+		rtt = sample
+	} else {
+		rtt = (7*rtt + sample) / 8
+	}
+	atomic.StoreInt64(&d.rtt, int64(rtt))
+	logrus.Debugf("Client round trip time %s", rtt)
+}
+
+// congested returns true if too many updates have not been acknowledged by the
+// client yet.
+func (d *Display) congested() bool {
+	if !d.clientSupportsPseudo(pseudoEncodingFence) {
+		return false
+	}
+	d.expireFences()
+	return len(d.fencesInFlight) >= maxUpdatesInFlight
+}
+
+func (d *Display) expireFences() {
+	for seq, sent := range d.fencesInFlight {
+		if time.Since(sent) > fenceTimeout {
+			delete(d.fencesInFlight, seq)
+		}
+	}
+}
+
+func (d *Display) writeFence(flags uint32, payload []byte) {
+	buf := new(bytes.Buffer)
+	utils.Write(buf, uint8(cmdFence))
+	utils.Write(buf, []byte{0, 0, 0}) // padding
+	utils.Write(buf, flags)
+	utils.Write(buf, uint8(len(payload)))
+	utils.Write(buf, payload)
+	d.buf.Dispatch(buf.Bytes())
+}
+
+// clientSupportsPseudo returns true if the client listed the given pseudo-encoding.
+func (d *Display) clientSupportsPseudo(code int32) bool {
+	for _, e := range d.pseudoEncodings {
+		if e == code {
+			return true
+		}
+	}
+	return false
+}
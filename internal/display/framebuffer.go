@@ -0,0 +1,202 @@
+package display
+
+import (
+	"image"
+	"image/draw"
+	"sync"
+
+	"github.com/suutaku/go-vnc/internal/clipboard"
+	"github.com/suutaku/go-vnc/internal/input"
+)
+
+// Framebuffer is a display drawn by an application. The application draws into
+// Image and calls MarkDirty or Flush to show clients what changed, and gets the
+// clients' input through its callbacks.
+//
+// Provider and InputSink return the display provider and input sink to serve
+// it with.
+type Framebuffer struct {
+	// OnKey is called when a key is pressed or released, with its X11 keysym.
+	OnKey func(keysym uint32, down bool)
+	// OnPointer is called when the pointer moves or a button changes, with the
+	// position and the buttons held, bit 0 for the left button.
+	OnPointer func(x, y int, buttons uint8)
+	// OnScroll is called when the wheel turns. Positive dx scrolls right and
+	// positive dy scrolls down.
+	OnScroll func(dx, dy int)
+
+	img *image.RGBA
+
+	// Copy of img as of the last MarkDirty, which the connections read from,
+	// and the connections to tell about changes.
+	mu    sync.Mutex
+	front *image.RGBA
+	views map[*framebufferView]struct{}
+
+	// Pointer state for OnPointer.
+	inputMu sync.Mutex
+	pos     image.Point
+	buttons uint8
+
+	clipboard clipboard.Memory
+}
+
+// NewFramebuffer returns a black framebuffer of the given size.
+func NewFramebuffer(width, height int) *Framebuffer {
+	r := image.Rect(0, 0, width, height)
+	fb := &Framebuffer{
+		img:   image.NewRGBA(r),
+		front: image.NewRGBA(r),
+		views: make(map[*framebufferView]struct{}),
+	}
+	for i := 3; i < len(fb.img.Pix); i += 4 {
+		fb.img.Pix[i], fb.front.Pix[i] = 0xff, 0xff
+	}
+	return fb
+}
+
+// Image returns the image the application draws into. Clients see the changes
+// once they are marked dirty.
+func (fb *Framebuffer) Image() *image.RGBA { return fb.img }
+
+// MarkDirty shows clients the given area of the image. The application must not
+// draw into the area until MarkDirty returns.
+func (fb *Framebuffer) MarkDirty(r image.Rectangle) {
+	r = r.Intersect(fb.img.Bounds())
+	if r.Empty() {
+		return
+	}
+	fb.mu.Lock()
+	defer fb.mu.Unlock()
+	draw.Draw(fb.front, r, fb.img, r.Min, draw.Src)
+	for v := range fb.views {
+		v.damage = v.damage.Union(r)
+		select {
+		case v.changed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Flush shows clients the whole image.
+func (fb *Framebuffer) Flush() { fb.MarkDirty(fb.img.Bounds()) }
+
+// Provider returns a new display provider showing the framebuffer.
+func (fb *Framebuffer) Provider() IDisplay {
+	return &framebufferView{fb: fb, changed: make(chan struct{}, 1)}
+}
+
+// InputSink returns the sink passing client input to the callbacks.
+func (fb *Framebuffer) InputSink() input.Sink { return &framebufferInput{fb: fb} }
+
+// framebufferView is a provider showing a framebuffer. It keeps track of the
+// area that changed since its last frame.
+type framebufferView struct {
+	fb      *Framebuffer
+	changed chan struct{}
+
+	// Guarded by fb.mu.
+	damage    image.Rectangle
+	lastPaint image.Rectangle
+}
+
+// Start starts following the framebuffer. Its size is that of the image, the
+// client is resized to it if needed.
+func (v *framebufferView) Start(width, height int) error {
+	v.fb.mu.Lock()
+	defer v.fb.mu.Unlock()
+	v.fb.views[v] = struct{}{}
+	v.damage = v.fb.front.Bounds()
+	v.changed <- struct{}{}
+	return nil
+}
+
+// PullFrame returns a copy of the current frame without waiting for a change.
+func (v *framebufferView) PullFrame() *image.RGBA {
+	v.fb.mu.Lock()
+	defer v.fb.mu.Unlock()
+	frame := image.NewRGBA(v.fb.front.Bounds())
+	copy(frame.Pix, v.fb.front.Pix)
+	v.lastPaint, v.damage = v.damage, image.Rectangle{}
+	return frame
+}
+
+// Damage returns the area that changed in the last frame pulled.
+func (v *framebufferView) Damage() image.Rectangle {
+	v.fb.mu.Lock()
+	defer v.fb.mu.Unlock()
+	return v.lastPaint
+}
+
+// Changed returns the channel told about changes to the framebuffer.
+func (v *framebufferView) Changed() <-chan struct{} { return v.changed }
+
+// Close stops following the framebuffer.
+func (v *framebufferView) Close() error {
+	v.fb.mu.Lock()
+	defer v.fb.mu.Unlock()
+	delete(v.fb.views, v)
+	return nil
+}
+
+// framebufferInput passes client input to the callbacks of a framebuffer.
+type framebufferInput struct {
+	fb *Framebuffer
+}
+
+func (in *framebufferInput) KeyDown(keysym, scancode uint32) error {
+	if in.fb.OnKey != nil {
+		in.fb.OnKey(keysym, true)
+	}
+	return nil
+}
+
+func (in *framebufferInput) KeyUp(keysym, scancode uint32) error {
+	if in.fb.OnKey != nil {
+		in.fb.OnKey(keysym, false)
+	}
+	return nil
+}
+
+func (in *framebufferInput) PointerMove(x, y int) error {
+	in.pointer(func() bool {
+		moved := in.fb.pos != image.Pt(x, y)
+		in.fb.pos = image.Pt(x, y)
+		return moved
+	})
+	return nil
+}
+
+func (in *framebufferInput) ButtonDown(b input.Button) error {
+	in.pointer(func() bool {
+		in.fb.buttons |= 1 << b
+		return true
+	})
+	return nil
+}
+
+func (in *framebufferInput) ButtonUp(b input.Button) error {
+	in.pointer(func() bool {
+		in.fb.buttons &^= 1 << b
+		return true
+	})
+	return nil
+}
+
+func (in *framebufferInput) Scroll(dx, dy int) error {
+	if in.fb.OnScroll != nil {
+		in.fb.OnScroll(dx, dy)
+	}
+	return nil
+}
+
+func (in *framebufferInput) Clipboard() clipboard.Clipboard { return &in.fb.clipboard }
+
+// pointer updates the pointer state and calls OnPointer if it changed.
+func (in *framebufferInput) pointer(update func() bool) {
+	in.fb.inputMu.Lock()
+	defer in.fb.inputMu.Unlock()
+	if update() && in.fb.OnPointer != nil {
+		in.fb.OnPointer(in.fb.pos.X, in.fb.pos.Y, in.fb.buttons)
+	}
+}
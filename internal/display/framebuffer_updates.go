@@ -3,8 +3,10 @@ package display
 import (
 	"bytes"
 	"image"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/suutaku/go-vnc/internal/encodings"
 	"github.com/suutaku/go-vnc/internal/types"
 	"github.com/suutaku/go-vnc/internal/utils"
 )
@@ -15,58 +17,226 @@ const (
 	cmdFramebufferUpdate = 0
 )
 
-func (d *Display) pushFrame(ur *types.FrameBufferUpdateRequest) {
+// Retrying updates held back by the frame rate cap or congestion.
+const defaultFramePollInterval = 50 * time.Millisecond
 
-	li := d.GetLastImage()
-	if li == nil {
-		return
-	}
+// updateRequest is the FramebufferUpdateRequest the client is waiting on.
+// Requests arriving while one is outstanding are merged into it.
+type updateRequest struct {
+	area image.Rectangle
+	full bool
+}
 
-	if ur.Incremental() {
-		li = truncateImage(ur, li)
+// queueRequest records a FramebufferUpdateRequest from the client.
+func (d *Display) queueRequest(ur *types.FrameBufferUpdateRequest) {
+	area := requestRect(ur)
+	if d.pending == nil {
+		d.pending = &updateRequest{area: area, full: !ur.Incremental()}
+		return
 	}
-	d.pushImage(li)
+	d.pending.area = d.pending.area.Union(area)
+	d.pending.full = d.pending.full || !ur.Incremental()
 }
 
-func (d *Display) pushImage(img *image.RGBA) {
+// servePending answers the outstanding update request if there is something to
+// send. Non-incremental requests are answered right away, incremental ones are
+// held until the requested area changes and the frame rate cap allows it.
+//
+// With continuous updates enabled, changes to the continuous area are sent
+// without waiting for requests, and incremental requests are covered by them.
+// Updates are then also held while the client is behind on acknowledging them.
+//
+// After a size change the next update covers the whole framebuffer.
+func (d *Display) servePending() {
+	if d.pending == nil && !d.continuous {
+		return
+	}
+	full := (d.pending != nil && d.pending.full) || d.needFullUpdate
+	if !full && (time.Since(d.lastUpdate) < d.minUpdateInterval || (d.continuous && d.congested())) {
+		return
+	}
 
-	b := img.Bounds()
+	li := d.nextFrame()
+	if li == nil {
+		return
+	}
+	if li = d.fitFrame(li); li == nil {
+		return
+	}
+	li = d.updateCursor(li)
+
+	var area image.Rectangle
+	switch {
+	case d.needFullUpdate:
+		full, area = true, li.Bounds()
+	case full:
+		area = d.pending.area
+	default:
+		if d.pending != nil {
+			area = d.pending.area
+		}
+		if d.continuous {
+			area = area.Union(d.continuousArea)
+		}
+	}
+	area = li.Bounds().Intersect(area)
+	if d.pushImage(li, area, full) {
+		d.pending = nil
+		d.needFullUpdate = false
+		d.lastUpdate = time.Now()
+		d.sendUpdateFence()
+	}
+}
 
-	buf := new(bytes.Buffer)
+// framePollInterval returns how often held updates are retried.
+func (d *Display) framePollInterval() time.Duration {
+	if d.minUpdateInterval > 0 {
+		return d.minUpdateInterval
+	}
+	return defaultFramePollInterval
+}
 
-	utils.Write(buf, uint8(cmdFramebufferUpdate))
-	utils.Write(buf, uint8(0))  // padding byte
-	utils.Write(buf, uint16(1)) // 1 rectangle
+// pushImage sends the parts of the given area of img that changed since the
+// last update to the client, or the whole area when full is set. Content the
+// client already has elsewhere on its screen is sent with CopyRect, the rest
+// with the current encoding. Nothing is sent if nothing changed, except for a
+// full update which is always answered. It returns true if an update was sent.
+func (d *Display) pushImage(img *image.RGBA, area image.Rectangle, full bool) bool {
 
 	//logrus.Printf("sending %d x %d pixels", width, height)
 	format := d.GetPixelFormat()
 	if format.TrueColour == 0 {
 		logrus.Error("only true-colour supported")
-		return
+		return false
 	}
 
 	enc := d.GetCurrentEncoding()
-	// Send that rectangle:
-	utils.PackStruct(buf, &types.FrameBufferRectangle{
-		X: uint16(b.Min.X), Y: uint16(b.Min.Y), Width: uint16(b.Max.X - b.Min.X), Height: uint16(b.Max.Y - b.Min.Y), EncType: enc.Code(), // TODO make sure supported
-	})
+	if enc == nil {
+		logrus.Debug("client has not set any supported encoding yet")
+		return false
+	}
+
+	var damage []image.Rectangle
+	if !area.Empty() {
+		damage = []image.Rectangle{area}
+	}
+	var copies []copyRect
+	if !full && d.lastSent != nil && d.lastSent.Bounds() == img.Bounds() {
+		changed := d.changedArea(area)
+		damage = damagedRects(d.lastSent, img, changed)
+		if len(damage) > 0 && d.clientSupports(encodingCopyRect) {
+			copies = detectCopies(d.lastSent, img, changed)
+		}
+	}
+	// Everything changed in area is now either sent or found unchanged.
+	if d.damage.In(area) {
+		d.damage = image.Rectangle{}
+	}
+	remaining := damage
+	for _, c := range copies {
+		remaining = subtractRect(remaining, c.dst)
+	}
+	pseudo := d.takePseudoRects()
+	if !full && len(pseudo) == 0 && len(copies) == 0 && len(remaining) == 0 {
+		return false
+	}
+
+	rects := make([]image.Rectangle, 0, len(remaining))
+	for _, r := range remaining {
+		if splitter, ok := enc.(encodings.RectSplitter); ok {
+			rects = append(rects, splitter.SplitRect(r)...)
+		} else {
+			rects = append(rects, r)
+		}
+	}
 
-	enc.HandleBuffer(buf, d.GetPixelFormat(), img)
+	buf := new(bytes.Buffer)
+	utils.Write(buf, uint8(cmdFramebufferUpdate))
+	utils.Write(buf, uint8(0))                                   // padding byte
+	utils.Write(buf, uint16(len(pseudo)+len(copies)+len(rects))) // number of rectangles
+
+	// Pseudo-rectangles go first, a new framebuffer size applies to the rest.
+	for _, p := range pseudo {
+		buf.Write(p)
+	}
+	// Copies go before the encoded rectangles, so their sources are still what
+	// the client had on screen.
+	for _, c := range copies {
+		utils.PackStruct(buf, rectHeader(c.dst, encodingCopyRect))
+		utils.Write(buf, uint16(c.src.X))
+		utils.Write(buf, uint16(c.src.Y))
+	}
+	for _, r := range rects {
+		utils.PackStruct(buf, rectHeader(r, enc.Code()))
+		enc.HandleBuffer(buf, format, img.SubImage(r).(*image.RGBA))
+	}
 	d.buf.Dispatch(buf.Bytes())
+	for _, c := range copies {
+		d.rememberSent(img, c.dst)
+	}
+	for _, r := range remaining {
+		d.rememberSent(img, r)
+	}
+	return true
 }
 
-func truncateImage(ur *types.FrameBufferUpdateRequest, img *image.RGBA) *image.RGBA {
-	truncated := image.NewRGBA(
-		image.Rect(
-			int(ur.X), int(ur.Y), int(ur.Width), int(ur.Height),
-		),
-	)
+// changedArea returns the part of area that may have changed since the last
+// update.
+func (d *Display) changedArea(area image.Rectangle) image.Rectangle {
+	return area.Intersect(d.damage)
+}
 
-	for y := ur.Y; y < ur.Height; y++ {
-		for x := ur.X; x < ur.Width; x++ {
-			truncated.Set(int(x), int(y), img.At(int(x), int(y)))
+// queuePseudoRect queues a pseudo-encoding rectangle to be sent at the start of
+// the next update. It is safe to call from any goroutine.
+func (d *Display) queuePseudoRect(rect *types.FrameBufferRectangle, data []byte) {
+	buf := new(bytes.Buffer)
+	utils.PackStruct(buf, rect)
+	buf.Write(data)
+	d.pseudoMu.Lock()
+	defer d.pseudoMu.Unlock()
+	d.pseudoRects = append(d.pseudoRects, buf.Bytes())
+}
+
+func (d *Display) takePseudoRects() [][]byte {
+	d.pseudoMu.Lock()
+	defer d.pseudoMu.Unlock()
+	out := d.pseudoRects
+	d.pseudoRects = nil
+	return out
+}
+
+// rememberSent records what the client has on screen after area of img was
+// sent. Nothing is recorded until the client has seen a full frame, as the
+// rest of its screen is unknown until then.
+func (d *Display) rememberSent(img *image.RGBA, area image.Rectangle) {
+	if d.lastSent == nil || d.lastSent.Bounds() != img.Bounds() {
+		if area != img.Bounds() {
+			d.lastSent = nil
+			return
+		}
+		d.lastSent = image.NewRGBA(img.Bounds())
+	}
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		copy(rowPix(d.lastSent, area.Min.X, area.Max.X, y), rowPix(img, area.Min.X, area.Max.X, y))
+	}
+}
+
+// clientSupports returns true if the client listed the given encoding.
+func (d *Display) clientSupports(code int32) bool {
+	for _, e := range d.GetEncodings() {
+		if e == code {
+			return true
 		}
 	}
+	return false
+}
+
+func rectHeader(r image.Rectangle, enc int32) *types.FrameBufferRectangle {
+	return &types.FrameBufferRectangle{
+		X: uint16(r.Min.X), Y: uint16(r.Min.Y), Width: uint16(r.Dx()), Height: uint16(r.Dy()), EncType: enc,
+	}
+}
 
-	return truncated
+func requestRect(ur *types.FrameBufferUpdateRequest) image.Rectangle {
+	return image.Rect(int(ur.X), int(ur.Y), int(ur.X)+int(ur.Width), int(ur.Y)+int(ur.Height))
 }
@@ -1,136 +1,95 @@
 package display
 
 import (
-	"github.com/go-vgo/robotgo"
+	"strings"
+	"unicode"
+
 	"github.com/sirupsen/logrus"
+	"github.com/suutaku/go-vnc/internal/keysym"
+	"github.com/suutaku/go-vnc/internal/types"
 )
 
-func (d *Display) dispatchDownKeys() {
-	if len(d.downKeys) == 1 {
-		ks, ok := robotASCIMap[d.downKeys[0]]
-		if !ok {
-			logrus.Println("Unhandled keysym:", d.downKeys[0])
-			return
-		}
-		robotgo.KeyTap(ks)
+// pseudoEncodingQEMUExtendedKeys announces support for QEMU extended key events.
+const pseudoEncodingQEMUExtendedKeys = -258
+
+// announceExtendedKeys acknowledges the QEMU extended key events pseudo-encoding
+// the first time the client lists it, after which the client sends scancodes.
+func (d *Display) announceExtendedKeys() {
+	if !d.announcedExtendedKeys && d.clientSupportsPseudo(pseudoEncodingQEMUExtendedKeys) {
+		d.announcedExtendedKeys = true
+		d.queuePseudoRect(&types.FrameBufferRectangle{EncType: pseudoEncodingQEMUExtendedKeys}, nil)
+	}
+}
+
+// pressKey presses the key of a key-down event. Further down events for a held
+// key are the client's auto-repeat and press it again.
+func (d *Display) pressKey(ev *types.KeyEvent) {
+	if err := d.input.KeyDown(ev.Key, ev.Scancode); err != nil {
+		logrus.Error("Failed to press key: ", err)
 		return
 	}
-	args := make([]interface{}, len(d.downKeys))
-	for idx, key := range d.downKeys {
-		ks, ok := robotASCIMap[key]
-		if !ok {
-			logrus.Println("Unhandled keysym:", d.downKeys[0])
-			return
-		}
-		args[len(d.downKeys)-1-idx] = ks
+	if d.heldKeyIndex(ev) < 0 {
+		d.downKeys = append(d.downKeys, ev)
 	}
-	robotgo.KeyTap(args[0].(string), args[1:]...)
 }
 
-func (d *Display) appendDownKeyIfMissing(downKey uint32) {
-	for _, k := range d.downKeys {
-		if k == downKey {
-			return
-		}
+// releaseKey releases the key of a key-up event if it is held. The key is
+// released as it was pressed, as the case of its keysym may have changed with
+// Shift since.
+func (d *Display) releaseKey(ev *types.KeyEvent) {
+	idx := d.heldKeyIndex(ev)
+	if idx < 0 {
+		return
+	}
+	held := d.downKeys[idx]
+	d.downKeys = append(d.downKeys[:idx], d.downKeys[idx+1:]...)
+	if err := d.input.KeyUp(held.Key, held.Scancode); err != nil {
+		logrus.Error("Failed to release key: ", err)
 	}
-	d.downKeys = append(d.downKeys, downKey)
 }
 
-func (d *Display) removeDownKey(downKey uint32) {
-	newDownKeys := make([]uint32, 0)
-	for _, k := range d.downKeys {
-		if k != downKey {
-			newDownKeys = append(newDownKeys, k)
-		}
+// releaseKeys releases every key still held, last pressed first, so that no
+// key stays down on the host after the client disconnects.
+func (d *Display) releaseKeys() {
+	for len(d.downKeys) > 0 {
+		d.releaseKey(d.downKeys[len(d.downKeys)-1])
 	}
-	d.downKeys = newDownKeys
 }
 
-var robotASCIMap = map[uint32]string{
-	uint32('a'): "a", uint32('A'): "A",
-	uint32('b'): "b", uint32('B'): "B",
-	uint32('c'): "c", uint32('C'): "C",
-	uint32('d'): "d", uint32('D'): "D",
-	uint32('e'): "e", uint32('E'): "E",
-	uint32('f'): "f", uint32('F'): "F",
-	uint32('g'): "g", uint32('G'): "G",
-	uint32('h'): "h", uint32('H'): "H",
-	uint32('i'): "i", uint32('I'): "I",
-	uint32('j'): "j", uint32('J'): "J",
-	uint32('k'): "k", uint32('K'): "K",
-	uint32('l'): "l", uint32('L'): "L",
-	uint32('m'): "m", uint32('M'): "M",
-	uint32('n'): "n", uint32('N'): "N",
-	uint32('o'): "o", uint32('O'): "O",
-	uint32('p'): "p", uint32('P'): "P",
-	uint32('q'): "q", uint32('Q'): "Q",
-	uint32('r'): "r", uint32('R'): "R",
-	uint32('s'): "s", uint32('S'): "S",
-	uint32('t'): "t", uint32('T'): "T",
-	uint32('u'): "u", uint32('U'): "U",
-	uint32('v'): "v", uint32('V'): "V",
-	uint32('w'): "w", uint32('W'): "W",
-	uint32('x'): "x", uint32('X'): "X",
-	uint32('y'): "y", uint32('Y'): "Y",
-	uint32('z'): "z", uint32('Z'): "Z",
-
-	uint32(','): ",", uint32('.'): ".", uint32('/'): "/",
-	uint32(';'): ";", uint32('\''): "'",
-	uint32('['): "[", uint32(']'): "]", uint32('\\'): "\\",
-	uint32('-'): "-", uint32('+'): "+",
-
-	32: "space",
-
-	0xff08: "backspace",
-	0xffff: "delete",
-	0xff0d: "enter",
-	0xff09: "tab",
-	0xff1b: "esc",
-	0xff52: "up",    // Up arrow key
-	0xff54: "down",  // Down arrow key
-	0xff53: "right", // Right arrow key
-	0xff51: "left",  // Left arrow key
-	0xff50: "home",
-	0xff57: "end",
-	0xff55: "pageup",
-	0xff56: "pagedown",
-
-	0xffbe: "f1",
-	0xffbf: "f2",
-	0xffc0: "f3",
-	0xffc1: "f4",
-	0xffc2: "f5",
-	0xffc3: "f6",
-	0xffc4: "f7",
-	0xffc5: "f8",
-	0xffc6: "f9",
-	0xffc7: "f10",
-	0xffc8: "f11",
-	0xffc9: "f12",
+func (d *Display) heldKeyIndex(ev *types.KeyEvent) int {
+	for idx, k := range d.downKeys {
+		if sameKey(k, ev) {
+			return idx
+		}
+	}
+	return -1
+}
 
-	0xffe7: "lcmd",   // left command
-	0xffe8: "rcmd",   // right command
-	0xffe9: "lalt",   // left alt
-	0xffea: "ralt",   // right alt
-	0xffe3: "lctrl",  // left ctrl
-	0xffe4: "rctrl",  // right ctrl
-	0xffe1: "lshift", // left shift
-	0xffe2: "rshift", // right shift
-	0xffe5: "capslock",
-	0xff80: "space",
-	0xff61: "print",
-	0xfd1d: "printscreen", // No Mac support
-	0xff9e: "insert",
-	0xff67: "menu", //	Windows only
+// sameKey returns true if both events are for the same key. Keysyms change
+// with the modifiers held, so scancodes are compared when both have one.
+// Otherwise the keys are compared without Shift, as a key pressed with Shift
+// held, such as A or !, can be released as a or 1 after Shift was.
+func sameKey(a, b *types.KeyEvent) bool {
+	if a.Scancode != 0 && b.Scancode != 0 {
+		return a.Scancode == b.Scancode
+	}
+	if a.Key == b.Key {
+		return true
+	}
+	ka, kb := foldedKey(a.Key), foldedKey(b.Key)
+	return ka != "" && ka == kb
+}
 
-	0xffb0: "0",
-	0xffb1: "1",
-	0xffb2: "2",
-	0xffb3: "3",
-	0xffb4: "4",
-	0xffb5: "5",
-	0xffb6: "6",
-	0xffb7: "7",
-	0xffb8: "8",
-	0xffb9: "9",
+// foldedKey returns the key the input backend presses for a keysym without
+// Shift, or the character it types in lower case. It is empty for other
+// keysyms.
+func foldedKey(ks uint32) string {
+	ks = keysym.BaseKey(ks)
+	if name, ok := keysym.KeyName(ks); ok {
+		return strings.ToLower(name)
+	}
+	if r, ok := keysym.Rune(ks); ok {
+		return string(unicode.ToLower(r))
+	}
+	return ""
 }
@@ -0,0 +1,116 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/suutaku/go-vnc/internal/input"
+	"github.com/suutaku/go-vnc/internal/types"
+)
+
+const keysymShiftL = 0xffe1
+
+// TestShiftReleasedFirst presses keys with Shift, releases Shift and then the
+// keys as the client sees them without Shift.
+func TestShiftReleasedFirst(t *testing.T) {
+	for _, c := range []struct{ pressed, released uint32 }{
+		{'A', 'a'},
+		{'!', '1'},
+		{'@', '2'},
+		{'?', '/'},
+		{'{', '['},
+		{'_', '-'},
+		{'"', '\''},
+		{'~', '`'},
+	} {
+		rec := input.NewRecorder()
+		d := NewDisplay(&Opts{InputSink: rec})
+		d.pressKey(&types.KeyEvent{Key: keysymShiftL})
+		d.pressKey(&types.KeyEvent{Key: c.pressed})
+		d.releaseKey(&types.KeyEvent{Key: keysymShiftL})
+		d.releaseKey(&types.KeyEvent{Key: c.released})
+
+		want := []input.Event{
+			{Type: input.EventKeyDown, Keysym: keysymShiftL},
+			{Type: input.EventKeyDown, Keysym: c.pressed},
+			{Type: input.EventKeyUp, Keysym: keysymShiftL},
+			{Type: input.EventKeyUp, Keysym: c.pressed},
+		}
+		if got := rec.Events(); !equalEvents(got, want) {
+			t.Errorf("%q released as %q: events %v, want %v", c.pressed, c.released, got, want)
+		}
+		if len(d.downKeys) != 0 {
+			t.Errorf("%q released as %q: still held %v", c.pressed, c.released, d.downKeys)
+		}
+	}
+}
+
+func TestDifferentKeysNotReleased(t *testing.T) {
+	rec := input.NewRecorder()
+	d := NewDisplay(&Opts{InputSink: rec})
+	d.pressKey(&types.KeyEvent{Key: '!'})
+	d.releaseKey(&types.KeyEvent{Key: '2'})
+	if len(d.downKeys) != 1 {
+		t.Fatal("! released by 2")
+	}
+}
+
+func equalEvents(a, b []input.Event) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestKeyEventsInOrder(t *testing.T) {
+	rec := input.NewRecorder()
+	d := startTestDisplay(t, rec)
+	defer d.Close()
+	for _, ev := range []*types.KeyEvent{
+		{DownFlag: 1, Key: keysymShiftL},
+		{DownFlag: 1, Key: 'A'},
+		{DownFlag: 0, Key: 'A'},
+		{DownFlag: 0, Key: keysymShiftL},
+		{DownFlag: 1, Key: 'b', Scancode: 0x30},
+		{DownFlag: 0, Key: 'b', Scancode: 0x30},
+	} {
+		d.DispatchKeyEvent(ev)
+	}
+	want := []input.Event{
+		{Type: input.EventKeyDown, Keysym: keysymShiftL},
+		{Type: input.EventKeyDown, Keysym: 'A'},
+		{Type: input.EventKeyUp, Keysym: 'A'},
+		{Type: input.EventKeyUp, Keysym: keysymShiftL},
+		{Type: input.EventKeyDown, Keysym: 'b', Scancode: 0x30},
+		{Type: input.EventKeyUp, Keysym: 'b', Scancode: 0x30},
+	}
+	if got := waitEvents(t, rec, len(want)); !equalEvents(got, want) {
+		t.Errorf("events %v, want %v", got, want)
+	}
+}
+
+func TestKeysReleasedOnClose(t *testing.T) {
+	rec := input.NewRecorder()
+	d := startTestDisplay(t, rec)
+	d.DispatchKeyEvent(&types.KeyEvent{DownFlag: 1, Key: keysymShiftL})
+	d.DispatchKeyEvent(&types.KeyEvent{DownFlag: 1, Key: 'x'})
+	// Auto-repeat presses the key again but it is released once.
+	d.DispatchKeyEvent(&types.KeyEvent{DownFlag: 1, Key: 'x'})
+	waitEvents(t, rec, 3)
+	d.Close()
+
+	want := []input.Event{
+		{Type: input.EventKeyDown, Keysym: keysymShiftL},
+		{Type: input.EventKeyDown, Keysym: 'x'},
+		{Type: input.EventKeyDown, Keysym: 'x'},
+		{Type: input.EventKeyUp, Keysym: 'x'},
+		{Type: input.EventKeyUp, Keysym: keysymShiftL},
+	}
+	if got := waitEvents(t, rec, len(want)); !equalEvents(got, want) {
+		t.Errorf("events %v, want %v", got, want)
+	}
+}
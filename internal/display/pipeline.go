@@ -0,0 +1,218 @@
+package display
+
+import (
+	"errors"
+	"image"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Frame is a frame published by a Pipeline. Its image is shared by all the
+// subscribers and must not be modified.
+type Frame struct {
+	Image *image.RGBA
+	// Seq numbers the frames of a pipeline in the order they were captured.
+	Seq uint64
+	// Damage is the area that changed since the previous frame the subscriber
+	// took, all of the frame for the first one.
+	Damage image.Rectangle
+}
+
+// Pipeline runs a single display provider for any number of connections. One
+// capture loop pulls frames from the provider and publishes them to every
+// subscriber. Subscribers that fall behind skip to the newest frame.
+//
+// The provider is started when the first connection subscribes, and closed
+// when the last one leaves. A new provider is created for the next one. A
+// provider whose PullFrame returns nil has stopped, and is replaced when the
+// next connection subscribes.
+type Pipeline struct {
+	name      Provider
+	frameRate int
+
+	mu       sync.Mutex
+	provider IDisplay
+	subs     map[*Subscription]struct{}
+	last     *Frame
+	seq      uint64
+	stop     chan struct{}
+}
+
+// NewPipeline returns a pipeline for the named provider. Providers generating
+// frames are asked for frameRate frames a second, unless it is zero.
+func NewPipeline(p Provider, frameRate int) *Pipeline {
+	return &Pipeline{
+		name:      p,
+		frameRate: frameRate,
+		subs:      make(map[*Subscription]struct{}),
+	}
+}
+
+// Provider returns the running provider, or nil if nobody is subscribed.
+func (p *Pipeline) Provider() IDisplay {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.provider
+}
+
+// Subscribe returns a subscription to the frames of the pipeline, starting the
+// provider with the given size if it is not running yet.
+func (p *Pipeline) Subscribe(width, height int) (*Subscription, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.provider == nil {
+		provider := GetDisplayProvider(p.name)
+		if provider == nil {
+			return nil, errors.New("display provider is invalid: " + string(p.name))
+		}
+		if setter, ok := provider.(FrameRateSetter); ok && p.frameRate > 0 {
+			setter.SetFrameRate(p.frameRate)
+		}
+		if err := provider.Start(width, height); err != nil {
+			return nil, err
+		}
+		p.provider, p.last = provider, nil
+		p.stop = make(chan struct{})
+		go p.capture(provider, p.stop)
+	}
+
+	sub := &Subscription{pipeline: p, ready: make(chan struct{}, 1)}
+	p.subs[sub] = struct{}{}
+	if p.last != nil {
+		sub.offer(p.last)
+	}
+	return sub, nil
+}
+
+// unsubscribe removes a subscription, closing the provider after the last one.
+func (p *Pipeline) unsubscribe(sub *Subscription) error {
+	p.mu.Lock()
+	if _, ok := p.subs[sub]; !ok {
+		p.mu.Unlock()
+		return nil
+	}
+	delete(p.subs, sub)
+	if len(p.subs) > 0 || p.provider == nil {
+		p.mu.Unlock()
+		return nil
+	}
+	provider := p.provider
+	close(p.stop)
+	p.provider, p.last = nil, nil
+	p.mu.Unlock()
+	return provider.Close()
+}
+
+// capture pulls frames from the provider until stopped, or until the provider
+// stops itself. Providers reporting damage are only pulled from when they have
+// changed.
+func (p *Pipeline) capture(provider IDisplay, stop chan struct{}) {
+	dp, damageKnown := provider.(DamageProvider)
+	var changed <-chan struct{}
+	if damageKnown {
+		changed = dp.Changed()
+	}
+	for {
+		if damageKnown {
+			select {
+			case <-stop:
+				return
+			case <-changed:
+			}
+		}
+		img := provider.PullFrame()
+		if img == nil {
+			p.end(stop)
+			return
+		}
+		damage := img.Bounds()
+		if damageKnown {
+			damage = dp.Damage()
+		}
+		if !p.publish(stop, img, damage) {
+			return
+		}
+	}
+}
+
+// end forgets a provider that stopped producing frames, unless the capture
+// loop it ran in was stopped already. The provider stopped itself, so it is not
+// closed again.
+func (p *Pipeline) end(stop chan struct{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stop != stop || p.provider == nil {
+		return
+	}
+	logrus.Error("Display provider ", p.name, " stopped, it is restarted for the next connection")
+	close(p.stop)
+	p.provider, p.last, p.stop = nil, nil, nil
+}
+
+// publish hands a frame to every subscriber. It returns false if the capture
+// loop the frame came from was stopped.
+func (p *Pipeline) publish(stop chan struct{}, img *image.RGBA, damage image.Rectangle) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stop != stop || p.provider == nil {
+		return false
+	}
+	p.seq++
+	if p.last != nil && p.last.Image.Bounds() != img.Bounds() {
+		damage = img.Bounds()
+	}
+	p.last = &Frame{Image: img, Seq: p.seq, Damage: damage}
+	for sub := range p.subs {
+		sub.offer(p.last)
+	}
+	logrus.Trace("published frame ", p.seq, " to ", len(p.subs), " subscribers")
+	return true
+}
+
+// Subscription receives the frames of a pipeline for a single connection.
+type Subscription struct {
+	pipeline *Pipeline
+	ready    chan struct{}
+
+	mu   sync.Mutex
+	next *Frame
+	seen bool
+}
+
+// Ready returns a channel that receives a value when a new frame can be taken.
+func (s *Subscription) Ready() <-chan struct{} { return s.ready }
+
+// Next returns the newest frame not taken yet, or nil if there is none. Its
+// damage covers the frames skipped since the last one taken.
+func (s *Subscription) Next() *Frame {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f := s.next
+	s.next = nil
+	return f
+}
+
+// Close ends the subscription.
+func (s *Subscription) Close() error { return s.pipeline.unsubscribe(s) }
+
+// offer makes f the next frame, merging the damage of one not taken yet.
+func (s *Subscription) offer(f *Frame) {
+	s.mu.Lock()
+	next := *f
+	switch {
+	case !s.seen:
+		next.Damage = f.Image.Bounds()
+		s.seen = true
+	case s.next != nil:
+		next.Damage = next.Damage.Union(s.next.Damage)
+	}
+	s.next = &next
+	s.mu.Unlock()
+
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
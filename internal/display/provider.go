@@ -2,6 +2,7 @@ package display
 
 import (
 	"image"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 )
@@ -13,22 +14,88 @@ import (
 type IDisplay interface {
 	// Start should take care of any requirements for starting a feed to the frame buffer.
 	Start(width, height int) error
-	// PullFrame should return a queued frame for processing.
+	// PullFrame should return a queued frame for processing. It is called in
+	// a loop by the pipeline shared by all connections, so it must block until
+	// a new frame exists, unless the provider is a DamageProvider. Nil means
+	// the provider stopped.
 	PullFrame() *image.RGBA
-	// Close should stop any background processes from running.
+	// Close should stop any background processes from running. It is called
+	// after the last connection left.
 	Close() error
 }
 
+// DamageProvider is implemented by display providers that know which parts of
+// their frames change, such as frames drawn by an application. Frames are then
+// only pulled when a change is reported, and only compared with what clients
+// have within the changed parts.
+type DamageProvider interface {
+	// Damage returns the area of the last frame pulled that changed since the
+	// frame before it.
+	Damage() image.Rectangle
+	// Changed returns a channel that receives a value when a new frame can be
+	// pulled.
+	Changed() <-chan struct{}
+}
+
+// DefaultSizer is implemented by display providers with a natural frame size,
+// such as that of the screen, used when the server is not given a size.
+type DefaultSizer interface {
+	DefaultSize() (width, height int)
+}
+
+// FrameRateSetter is implemented by display providers generating frames at a
+// configurable rate.
+type FrameRateSetter interface {
+	// SetFrameRate sets the number of frames a second. It is called before
+	// Start.
+	SetFrameRate(fps int)
+}
+
+// Frame size used when neither the server nor the provider give one.
+const (
+	fallbackWidth  = 1024
+	fallbackHeight = 768
+)
+
+// DefaultSize returns the natural frame size of the given provider.
+func DefaultSize(p Provider) (width, height int) {
+	if sizer, ok := GetDisplayProvider(p).(DefaultSizer); ok {
+		if w, h := sizer.DefaultSize(); w > 0 && h > 0 {
+			return w, h
+		}
+	}
+	return fallbackWidth, fallbackHeight
+}
+
 // Provider is an enum used for selecting a display provider.
 type Provider string
 
 // Provider options.
 const (
-	ProviderGstreamer  = "gstreamer"
-	ProviderScreenShot = "screenshot"
+	ProviderGstreamer   = "gstreamer"
+	ProviderScreenShot  = "screenshot"
+	ProviderTestPattern = "testpattern"
+)
+
+// ProviderFactory returns a new display provider. It is called when the first
+// connection of a server subscribes to its pipeline.
+type ProviderFactory func() IDisplay
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[Provider]ProviderFactory)
 )
 
-// GetDisplayProvider returns the provider to use for the given RFB connection.
+// RegisterProvider makes a display provider available under the given name.
+// Registering a name again replaces it, the built-in providers cannot be
+// replaced.
+func RegisterProvider(p Provider, f ProviderFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[p] = f
+}
+
+// GetDisplayProvider returns a new provider of the given name, or nil if there is none.
 func GetDisplayProvider(p Provider) IDisplay {
 	switch p {
 	case ProviderGstreamer:
@@ -38,6 +105,16 @@ func GetDisplayProvider(p Provider) IDisplay {
 	case ProviderScreenShot:
 		logrus.Info("screen shot provider")
 		return &ScreenShot{}
+	case ProviderTestPattern:
+		logrus.Info("test pattern provider")
+		return NewTestPattern(0)
+	}
+	registryMu.Lock()
+	f, ok := registry[p]
+	registryMu.Unlock()
+	if !ok {
+		return nil
 	}
-	return nil
+	logrus.Info(p, " provider")
+	return f()
 }
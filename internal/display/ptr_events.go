@@ -1,57 +1,71 @@
 package display
 
 import (
-	"github.com/go-vgo/robotgo"
+	"github.com/sirupsen/logrus"
+	"github.com/suutaku/go-vnc/internal/input"
 	"github.com/suutaku/go-vnc/internal/types"
 )
 
+// Bits of the RFB button mask. The wheel is sent as a press and release of
+// one of the scroll buttons per step.
+const (
+	btnMaskButtons     = 0x07 // left, middle and right
+	btnMaskScrollUp    = 1 << 3
+	btnMaskScrollDown  = 1 << 4
+	btnMaskScrollLeft  = 1 << 5
+	btnMaskScrollRight = 1 << 6
+)
+
 func (d *Display) servePointerEvent(ev *types.PointerEvent) {
-	robotgo.Move(int(ev.X), int(ev.Y))
-	btns := make(map[string]bool)
-	for mask, maskType := range btnMasks {
-		btns[maskType] = nthBitOf(ev.ButtonMask, mask) == 1
-		if btns[maskType] {
-			robotgo.MouseDown(robotGoKeyNames[maskType])
-		} else {
-			robotgo.MouseUp(robotGoKeyNames[maskType])
-		}
+	if err := d.input.PointerMove(int(ev.X), int(ev.Y)); err != nil {
+		logrus.Error("Failed to move pointer: ", err)
 	}
+	d.setButtons(ev.ButtonMask & btnMaskButtons)
 
-	// for k, v := range btns {
-	// 	switch k {
-	// 	case "left", "middle", "right", "scroll-up", "scroll-down", "scroll-left", "scroll-right":
-	// 		if v {
-	// 			robotgo.MouseDown(robotGoKeyNames[k])
-	// 		} else {
-	// 			robotgo.MouseUp(robotGoKeyNames[k])
-	// 		}
-	// 	case "unhandled":
-	// 	}
-	// }
-}
-
-var btnMasks = map[int]string{
-	0: "left",
-	1: "middle",
-	2: "right",
-	3: "scroll-up",
-	4: "scroll-down",
-	5: "scroll-left",
-	6: "scroll-right",
-	7: "unhandled",
+	pressed := ev.ButtonMask &^ d.buttonMask
+	var dx, dy int
+	if pressed&btnMaskScrollUp != 0 {
+		dy--
+	}
+	if pressed&btnMaskScrollDown != 0 {
+		dy++
+	}
+	if pressed&btnMaskScrollLeft != 0 {
+		dx--
+	}
+	if pressed&btnMaskScrollRight != 0 {
+		dx++
+	}
+	if dx != 0 || dy != 0 {
+		if err := d.input.Scroll(dx, dy); err != nil {
+			logrus.Error("Failed to scroll: ", err)
+		}
+	}
+	d.buttonMask = ev.ButtonMask
 }
 
-var robotGoKeyNames = map[string]string{
-	"left":         "left",
-	"middle":       "center",
-	"right":        "right",
-	"scroll-up":    "wheelUp",
-	"scroll-down":  "wheelDown",
-	"scroll-left":  "wheelLeft",
-	"scroll-right": "wheelRight",
-	"unhandled":    "unhandled",
+// setButtons presses and releases the buttons whose state differs from the
+// given mask.
+func (d *Display) setButtons(mask uint8) {
+	for b := input.ButtonLeft; b <= input.ButtonRight; b++ {
+		bit := uint8(1) << b
+		if mask&bit == d.buttonMask&bit {
+			continue
+		}
+		var err error
+		if mask&bit != 0 {
+			err = d.input.ButtonDown(b)
+		} else {
+			err = d.input.ButtonUp(b)
+		}
+		if err != nil {
+			logrus.Error("Failed to set pointer button: ", err)
+		}
+	}
 }
 
-func nthBitOf(bit uint8, n int) uint8 {
-	return (bit & (1 << n)) >> n
+// releaseButtons releases the buttons still held when the client disconnects.
+func (d *Display) releaseButtons() {
+	d.setButtons(0)
+	d.buttonMask = 0
 }
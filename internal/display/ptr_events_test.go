@@ -0,0 +1,91 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/suutaku/go-vnc/internal/input"
+	"github.com/suutaku/go-vnc/internal/types"
+)
+
+func TestPointerEventsInOrder(t *testing.T) {
+	rec := input.NewRecorder()
+	d := startTestDisplay(t, rec)
+	defer d.Close()
+	d.DispatchPointerEvent(&types.PointerEvent{X: 1, Y: 2, ButtonMask: 0x01})
+	d.DispatchPointerEvent(&types.PointerEvent{X: 3, Y: 4, ButtonMask: 0x05})
+	d.DispatchPointerEvent(&types.PointerEvent{X: 5, Y: 6, ButtonMask: 0x04})
+	d.DispatchPointerEvent(&types.PointerEvent{X: 7, Y: 8})
+
+	want := []input.Event{
+		{Type: input.EventPointerMove, X: 1, Y: 2},
+		{Type: input.EventButtonDown, Button: input.ButtonLeft},
+		{Type: input.EventPointerMove, X: 3, Y: 4},
+		{Type: input.EventButtonDown, Button: input.ButtonRight},
+		{Type: input.EventPointerMove, X: 5, Y: 6},
+		{Type: input.EventButtonUp, Button: input.ButtonLeft},
+		{Type: input.EventPointerMove, X: 7, Y: 8},
+		{Type: input.EventButtonUp, Button: input.ButtonRight},
+	}
+	if got := waitEvents(t, rec, len(want)); !equalEvents(got, want) {
+		t.Errorf("events %v, want %v", got, want)
+	}
+}
+
+func TestButtonsReleasedOnClose(t *testing.T) {
+	rec := input.NewRecorder()
+	d := startTestDisplay(t, rec)
+	d.DispatchPointerEvent(&types.PointerEvent{X: 1, Y: 2, ButtonMask: 0x03})
+	waitEvents(t, rec, 3)
+	d.Close()
+
+	want := []input.Event{
+		{Type: input.EventPointerMove, X: 1, Y: 2},
+		{Type: input.EventButtonDown, Button: input.ButtonLeft},
+		{Type: input.EventButtonDown, Button: input.ButtonMiddle},
+		{Type: input.EventButtonUp, Button: input.ButtonLeft},
+		{Type: input.EventButtonUp, Button: input.ButtonMiddle},
+	}
+	if got := waitEvents(t, rec, len(want)); !equalEvents(got, want) {
+		t.Errorf("events %v, want %v", got, want)
+	}
+}
+
+// TestScroll sends each wheel step as a press and release of a scroll button,
+// as clients do, and a diagonal step pressing two at once.
+func TestScroll(t *testing.T) {
+	rec := input.NewRecorder()
+	d := startTestDisplay(t, rec)
+	defer d.Close()
+	for _, mask := range []uint8{
+		btnMaskScrollUp, 0,
+		btnMaskScrollDown, 0,
+		btnMaskScrollLeft, 0,
+		btnMaskScrollRight, 0,
+		btnMaskScrollDown | btnMaskScrollRight, 0,
+		// Holding a scroll button is a single step.
+		btnMaskScrollUp, btnMaskScrollUp, 0,
+	} {
+		d.DispatchPointerEvent(&types.PointerEvent{X: 10, Y: 20, ButtonMask: mask})
+	}
+
+	var got [][2]int
+	for _, ev := range waitEvents(t, rec, 19) {
+		switch ev.Type {
+		case input.EventScroll:
+			got = append(got, [2]int{ev.X, ev.Y})
+		case input.EventPointerMove:
+		default:
+			t.Errorf("unexpected event %v", ev)
+		}
+	}
+	want := [][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}, {1, 1}, {0, -1}}
+	if len(got) != len(want) {
+		t.Fatalf("scrolls %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("scrolls %v, want %v", got, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package display
+
+import (
+	"image"
+	"sort"
+)
+
+// subtractRect returns the parts of rects that are not covered by r.
+func subtractRect(rects []image.Rectangle, r image.Rectangle) []image.Rectangle {
+	out := make([]image.Rectangle, 0, len(rects))
+	for _, a := range rects {
+		if !a.Overlaps(r) {
+			out = append(out, a)
+			continue
+		}
+		i := a.Intersect(r)
+		// Bands above and below the intersection span the full width, the
+		// pieces left and right of it only its height.
+		for _, piece := range []image.Rectangle{
+			image.Rect(a.Min.X, a.Min.Y, a.Max.X, i.Min.Y),
+			image.Rect(a.Min.X, i.Max.Y, a.Max.X, a.Max.Y),
+			image.Rect(a.Min.X, i.Min.Y, i.Min.X, i.Max.Y),
+			image.Rect(i.Max.X, i.Min.Y, a.Max.X, i.Max.Y),
+		} {
+			if !piece.Empty() {
+				out = append(out, piece)
+			}
+		}
+	}
+	return out
+}
+
+// mergeRects joins non-overlapping rectangles, such as grid tiles, into fewer
+// larger ones. Rectangles sharing a row are joined horizontally first, then
+// runs with the same horizontal span are stacked vertically.
+func mergeRects(rects []image.Rectangle) []image.Rectangle {
+	if len(rects) < 2 {
+		return rects
+	}
+	sorted := make([]image.Rectangle, len(rects))
+	copy(sorted, rects)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Min.Y != sorted[j].Min.Y {
+			return sorted[i].Min.Y < sorted[j].Min.Y
+		}
+		return sorted[i].Min.X < sorted[j].Min.X
+	})
+
+	runs := make([]image.Rectangle, 0, len(sorted))
+	for _, r := range sorted {
+		if n := len(runs); n > 0 {
+			last := &runs[n-1]
+			if last.Min.Y == r.Min.Y && last.Max.Y == r.Max.Y && last.Max.X == r.Min.X {
+				last.Max.X = r.Max.X
+				continue
+			}
+		}
+		runs = append(runs, r)
+	}
+
+	out := make([]image.Rectangle, 0, len(runs))
+	open := make(map[[2]int]int) // horizontal span -> index in out of the rect ending on the previous row
+	for _, r := range runs {
+		span := [2]int{r.Min.X, r.Max.X}
+		if i, ok := open[span]; ok && out[i].Max.Y == r.Min.Y {
+			out[i].Max.Y = r.Max.Y
+			continue
+		}
+		open[span] = len(out)
+		out = append(out, r)
+	}
+	return out
+}
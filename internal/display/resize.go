@@ -0,0 +1,244 @@
+package display
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/suutaku/go-vnc/internal/types"
+	"github.com/suutaku/go-vnc/internal/utils"
+)
+
+// Pseudo-encodings for telling the client about framebuffer size changes.
+const (
+	pseudoEncodingDesktopSize         = -223
+	pseudoEncodingExtendedDesktopSize = -308
+)
+
+// Reasons for a size change, sent in the x position of an ExtendedDesktopSize
+// rectangle.
+const (
+	resizeReasonServer      = 0
+	resizeReasonClient      = 1
+	resizeReasonOtherClient = 2
+)
+
+// Results of a SetDesktopSize request, sent in the y position of the reply.
+const (
+	resizeResultOK          = 0
+	resizeResultProhibited  = 1
+	resizeResultNoResources = 2
+	resizeResultInvalid     = 3
+)
+
+// Frames of the old size can still be on their way after a requested resize.
+// For this long they are dropped instead of being taken for a size change on
+// the server side.
+const resizeGracePeriod = time.Second
+
+// Resizer is implemented by display providers that can change the size of their
+// frames.
+type Resizer interface {
+	Resize(width, height int) error
+}
+
+// ResizeFunc is called after a client changed the framebuffer size and screen
+// layout, so other clients can follow.
+type ResizeFunc func(origin *Display, width, height int, screens []types.Screen)
+
+// resizeRequest is a size change waiting to be handled by the framebuffer
+// update loop.
+type resizeRequest struct {
+	width, height int
+	screens       []types.Screen
+	reason        int
+	// prohibited is set for requests the client has no permission for.
+	prohibited bool
+}
+
+// DispatchSetDesktopSize dispatches a client's request for a new framebuffer
+// size and screen layout.
+func (d *Display) DispatchSetDesktopSize(req *types.SetDesktopSize) {
+	d.resizeQueue <- &resizeRequest{
+		width: int(req.Width), height: int(req.Height), screens: req.Screens, reason: resizeReasonClient,
+	}
+}
+
+// RefuseSetDesktopSize tells the client its request for a new framebuffer size
+// is not allowed.
+func (d *Display) RefuseSetDesktopSize(req *types.SetDesktopSize) {
+	d.resizeQueue <- &resizeRequest{
+		width: int(req.Width), height: int(req.Height), screens: req.Screens, reason: resizeReasonClient, prohibited: true,
+	}
+}
+
+// DispatchLayoutChange tells the display another client changed the framebuffer
+// size and screen layout. Only the latest layout matters, so one still waiting
+// to be handled is replaced. It never blocks, as the server's connection lock
+// is held.
+func (d *Display) DispatchLayoutChange(width, height int, screens []types.Screen) {
+	req := &resizeRequest{width: width, height: height, screens: screens, reason: resizeReasonOtherClient}
+	select {
+	case d.layoutQueue <- req:
+		return
+	default:
+	}
+	select {
+	case <-d.layoutQueue:
+		logrus.Debug("Replacing layout change not handled yet")
+	default:
+	}
+	select {
+	case d.layoutQueue <- req:
+	default:
+		logrus.Debug("Dropping layout change, channel full")
+	}
+}
+
+// handleResize applies a size change requested by this client or another one.
+func (d *Display) handleResize(req *resizeRequest) {
+	if req.reason == resizeReasonOtherClient {
+		if !d.clientCanResize() {
+			return
+		}
+		if resizer, ok := d.provider().(Resizer); ok {
+			if err := resizer.Resize(req.width, req.height); err != nil {
+				logrus.Error("Could not follow resize by another client: ", err)
+				return
+			}
+		}
+		d.applyLayout(req.width, req.height, req.screens, req.reason)
+		return
+	}
+
+	status := resizeResultProhibited
+	if !req.prohibited {
+		status = d.resizeProvider(req)
+	}
+	if status != resizeResultOK {
+		logrus.Infof("Refused resize to %dx%d with result %d", req.width, req.height, status)
+		d.queueResizeRect(req.reason, status)
+		return
+	}
+	d.applyLayout(req.width, req.height, req.screens, req.reason)
+	if d.onResize != nil {
+		d.onResize(d, req.width, req.height, req.screens)
+	}
+}
+
+// resizeProvider validates a client's SetDesktopSize request and resizes the
+// display provider for it, returning the result to report to the client.
+func (d *Display) resizeProvider(req *resizeRequest) int {
+	if !validLayout(req.width, req.height, req.screens) {
+		return resizeResultInvalid
+	}
+	if req.width == d.width && req.height == d.height {
+		// Only the layout changed.
+		return resizeResultOK
+	}
+	resizer, ok := d.provider().(Resizer)
+	if !ok {
+		return resizeResultProhibited
+	}
+	if err := resizer.Resize(req.width, req.height); err != nil {
+		logrus.Error("Could not resize display: ", err)
+		return resizeResultNoResources
+	}
+	return resizeResultOK
+}
+
+// applyLayout switches the display to a new size and layout and queues the
+// rectangle telling the client about it. The next update covers the whole
+// framebuffer, as the client's content is undefined after a resize.
+func (d *Display) applyLayout(width, height int, screens []types.Screen, reason int) {
+	logrus.Infof("Resizing display to %dx%d", width, height)
+	d.SetDimensions(width, height)
+	d.screens = screens
+	d.resizedAt = time.Now()
+	d.needFullUpdate = true
+	d.queueResizeRect(reason, resizeResultOK)
+}
+
+// fitFrame checks a frame against the framebuffer size the client knows. A
+// frame of a different size means the provider changed resolution, which the
+// client is told about if it can follow. Otherwise the frame is cropped or
+// padded to the old size. It returns nil for stale frames that predate a
+// requested resize.
+func (d *Display) fitFrame(img *image.RGBA) *image.RGBA {
+	size := img.Bounds().Size()
+	if size == image.Pt(d.width, d.height) {
+		return img
+	}
+	if !d.clientCanResize() {
+		out := image.NewRGBA(image.Rect(0, 0, d.width, d.height))
+		draw.Draw(out, out.Bounds(), img, img.Bounds().Min, draw.Src)
+		return out
+	}
+	if time.Since(d.resizedAt) < resizeGracePeriod {
+		return nil
+	}
+	var id uint32
+	if len(d.screens) > 0 {
+		id = d.screens[0].ID
+	}
+	screens := []types.Screen{{ID: id, Width: uint16(size.X), Height: uint16(size.Y)}}
+	d.applyLayout(size.X, size.Y, screens, resizeReasonServer)
+	return img
+}
+
+// announceDesktopSize sends the current screen layout the first time the client
+// lists ExtendedDesktopSize, which tells it that SetDesktopSize is supported.
+func (d *Display) announceDesktopSize() {
+	if !d.announcedDesktopSize && d.clientSupportsPseudo(pseudoEncodingExtendedDesktopSize) {
+		d.announcedDesktopSize = true
+		d.queueResizeRect(resizeReasonServer, resizeResultOK)
+	}
+}
+
+// queueResizeRect queues the rectangle telling the client about the current
+// framebuffer size and layout. Clients without ExtendedDesktopSize only hear
+// about successful changes.
+func (d *Display) queueResizeRect(reason, status int) {
+	switch {
+	case d.clientSupportsPseudo(pseudoEncodingExtendedDesktopSize):
+		buf := new(bytes.Buffer)
+		utils.Write(buf, uint8(len(d.screens)))
+		utils.Write(buf, []byte{0, 0, 0}) // padding
+		for i := range d.screens {
+			utils.PackStruct(buf, &d.screens[i])
+		}
+		d.queuePseudoRect(&types.FrameBufferRectangle{
+			X: uint16(reason), Y: uint16(status), Width: uint16(d.width), Height: uint16(d.height),
+			EncType: pseudoEncodingExtendedDesktopSize,
+		}, buf.Bytes())
+	case d.clientSupportsPseudo(pseudoEncodingDesktopSize) && status == resizeResultOK:
+		d.queuePseudoRect(&types.FrameBufferRectangle{
+			Width: uint16(d.width), Height: uint16(d.height), EncType: pseudoEncodingDesktopSize,
+		}, nil)
+	}
+}
+
+// clientCanResize returns true if the client can be told about size changes.
+func (d *Display) clientCanResize() bool {
+	return d.clientSupportsPseudo(pseudoEncodingExtendedDesktopSize) || d.clientSupportsPseudo(pseudoEncodingDesktopSize)
+}
+
+// validLayout returns true if every screen lies within the framebuffer and has
+// a unique id.
+func validLayout(width, height int, screens []types.Screen) bool {
+	if width <= 0 || height <= 0 || len(screens) == 0 {
+		return false
+	}
+	fb := image.Rect(0, 0, width, height)
+	ids := make(map[uint32]bool, len(screens))
+	for _, s := range screens {
+		r := image.Rect(int(s.X), int(s.Y), int(s.X)+int(s.Width), int(s.Y)+int(s.Height))
+		if r.Empty() || !r.In(fb) || ids[s.ID] {
+			return false
+		}
+		ids[s.ID] = true
+	}
+	return true
+}
@@ -0,0 +1,24 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/suutaku/go-vnc/internal/input"
+	"github.com/suutaku/go-vnc/internal/types"
+)
+
+// TestLayoutChangesCoalesce checks that layout changes from other clients do
+// not block while the display is not handling them, and that the latest one
+// is kept.
+func TestLayoutChangesCoalesce(t *testing.T) {
+	d := NewDisplay(&Opts{InputSink: input.NewRecorder(), Width: 64, Height: 48})
+	for w := 1; w <= 1000; w++ {
+		d.DispatchLayoutChange(w, 48, []types.Screen{{Width: uint16(w), Height: 48}})
+	}
+	if n := len(d.layoutQueue); n != 1 {
+		t.Fatalf("%d layout changes queued, want 1", n)
+	}
+	if req := <-d.layoutQueue; req.width != 1000 {
+		t.Errorf("queued layout is %d wide, want the latest, 1000", req.width)
+	}
+}
@@ -2,7 +2,9 @@ package display
 
 import (
 	"image"
+	"sync"
 
+	"github.com/go-vgo/robotgo"
 	"github.com/sirupsen/logrus"
 	"github.com/suutaku/screenshot/pkg/screenshot"
 	"golang.org/x/image/draw"
@@ -11,21 +13,30 @@ import (
 type ScreenShot struct {
 	frameQueue   chan *image.RGBA
 	stopCh       chan struct{}
+	stopOnce     sync.Once
 	screenshoter *screenshot.Screenshot
+
+	// Size of the frames, captures of a different size are scaled to it.
+	sizeMu        sync.Mutex
+	width, height int
+	captureSize   image.Point
 }
 
 func NewScreenShot() *ScreenShot {
 	return &ScreenShot{}
 }
 
+// DefaultSize returns the size of the screen.
+func (ss *ScreenShot) DefaultSize() (width, height int) { return robotgo.GetScreenSize() }
+
 // Start should take care of any requirements for starting a feed to the frame buffer.
 func (ss *ScreenShot) Start(width, height int) error {
 
 	ss.screenshoter = screenshot.NewScreenshot(0, 0, 0, 0)
 	ss.frameQueue = make(chan *image.RGBA, 2)
 	ss.stopCh = make(chan struct{})
-	dr := image.Rect(0, 0, width, height)
-	newImg := image.NewRGBA(dr)
+	ss.stopOnce = sync.Once{}
+	ss.width, ss.height = width, height
 	go func() {
 		logrus.Info("display [ScreenShot] start")
 		for {
@@ -35,9 +46,14 @@ func (ss *ScreenShot) Start(width, height int) error {
 				ss.Close()
 				return
 			}
-			if img.Bounds().Max.X > width || img.Bounds().Max.Y > height {
-				draw.CatmullRom.Scale(newImg, dr, img, img.Bounds(), draw.Over, nil)
-				img = newImg
+			ss.sizeMu.Lock()
+			ss.captureSize = img.Bounds().Size()
+			ss.sizeMu.Unlock()
+			if w, h := ss.size(); img.Bounds().Dx() != w || img.Bounds().Dy() != h {
+				dr := image.Rect(0, 0, w, h)
+				scaled := image.NewRGBA(dr)
+				draw.CatmullRom.Scale(scaled, dr, img, img.Bounds(), draw.Over, nil)
+				img = scaled
 			}
 			select {
 			case <-ss.stopCh:
@@ -55,16 +71,62 @@ func (ss *ScreenShot) Start(width, height int) error {
 	return nil
 }
 
-// PullFrame should return a queued frame for processing.
+// Resize changes the size of the frames. The captured screen is scaled to it.
+func (ss *ScreenShot) Resize(width, height int) error {
+	ss.sizeMu.Lock()
+	ss.width, ss.height = width, height
+	ss.sizeMu.Unlock()
+
+	// Drop the queued frames of the old size
+	for {
+		select {
+		case <-ss.frameQueue:
+		default:
+			return nil
+		}
+	}
+}
+
+func (ss *ScreenShot) size() (width, height int) {
+	ss.sizeMu.Lock()
+	defer ss.sizeMu.Unlock()
+	return ss.width, ss.height
+}
+
+// CursorShape returns a plain arrow, as the shape of the system cursor is not
+// available.
+func (ss *ScreenShot) CursorShape() *Cursor { return arrowCursor }
+
+// CursorPosition returns the position of the system pointer, scaled like the
+// captured frames.
+func (ss *ScreenShot) CursorPosition() image.Point {
+	x, y := robotgo.GetMousePos()
+	ss.sizeMu.Lock()
+	defer ss.sizeMu.Unlock()
+	if ss.captureSize.X == 0 || ss.captureSize.Y == 0 {
+		return image.Pt(x, y)
+	}
+	return image.Pt(x*ss.width/ss.captureSize.X, y*ss.height/ss.captureSize.Y)
+}
+
+// PullFrame should return a queued frame for processing. It returns nil once
+// capture has stopped.
 func (ss *ScreenShot) PullFrame() *image.RGBA {
-	return <-ss.frameQueue
+	select {
+	case img := <-ss.frameQueue:
+		return img
+	case <-ss.stopCh:
+		return nil
+	}
 }
 
 // Close should stop any background processes from running.
 func (ss *ScreenShot) Close() error {
-	ss.stopCh <- struct{}{}
-	if ss.screenshoter != nil {
-		ss.screenshoter.Close()
-	}
+	ss.stopOnce.Do(func() {
+		close(ss.stopCh)
+		if ss.screenshoter != nil {
+			ss.screenshoter.Close()
+		}
+	})
 	return nil
 }
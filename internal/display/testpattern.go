@@ -0,0 +1,213 @@
+package display
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// Defaults of the test pattern.
+const (
+	DefaultTestPatternFPS    = 30
+	DefaultTestPatternWidth  = 1280
+	DefaultTestPatternHeight = 720
+)
+
+// testPatternText scrolls along the text band of the test pattern.
+const testPatternText = "go-vnc test pattern  -  The quick brown fox jumps over the lazy dog 0123456789  -  "
+
+// Colour bars, at 75% like the SMPTE ones.
+var testPatternBars = []color.RGBA{
+	{0xbf, 0xbf, 0xbf, 0xff}, // white
+	{0xbf, 0xbf, 0x00, 0xff}, // yellow
+	{0x00, 0xbf, 0xbf, 0xff}, // cyan
+	{0x00, 0xbf, 0x00, 0xff}, // green
+	{0xbf, 0x00, 0xbf, 0xff}, // magenta
+	{0xbf, 0x00, 0x00, 0xff}, // red
+	{0x00, 0x00, 0xbf, 0xff}, // blue
+}
+
+// TestPattern is a display provider generating animated frames, for machines
+// without a screen. Frame n is always the same for a given size, so runs can be
+// compared. Such machines also need an input sink other than robotgo, which
+// needs a screen.
+type TestPattern struct {
+	fps        int
+	frameQueue chan *image.RGBA
+	stopCh     chan struct{}
+
+	sizeMu        sync.Mutex
+	width, height int
+}
+
+// NewTestPattern returns a test pattern generating fps frames a second,
+// DefaultTestPatternFPS if zero.
+func NewTestPattern(fps int) *TestPattern {
+	tp := &TestPattern{}
+	tp.SetFrameRate(fps)
+	return tp
+}
+
+// SetFrameRate sets the number of frames generated a second. It must be called
+// before Start.
+func (tp *TestPattern) SetFrameRate(fps int) {
+	if fps <= 0 {
+		fps = DefaultTestPatternFPS
+	}
+	tp.fps = fps
+}
+
+// DefaultSize returns the size used when the server is not given one.
+func (tp *TestPattern) DefaultSize() (width, height int) {
+	return DefaultTestPatternWidth, DefaultTestPatternHeight
+}
+
+// Start starts generating frames of the given size.
+func (tp *TestPattern) Start(width, height int) error {
+	if tp.fps <= 0 {
+		tp.fps = DefaultTestPatternFPS
+	}
+	tp.frameQueue = make(chan *image.RGBA, 2)
+	tp.stopCh = make(chan struct{})
+	tp.width, tp.height = width, height
+	go func() {
+		logrus.Info("display [TestPattern] start at ", tp.fps, " fps")
+		ticker := time.NewTicker(time.Second / time.Duration(tp.fps))
+		defer ticker.Stop()
+		for n := uint64(0); ; n++ {
+			w, h := tp.size()
+			img := TestPatternFrame(n, tp.fps, w, h)
+			select {
+			case <-tp.stopCh:
+				return
+			case tp.frameQueue <- img:
+			default:
+				// pop the oldest item off the queue
+				// and let the next frame try to get in
+				<-tp.frameQueue
+				tp.frameQueue <- img
+			}
+			select {
+			case <-tp.stopCh:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Resize changes the size of the frames.
+func (tp *TestPattern) Resize(width, height int) error {
+	tp.sizeMu.Lock()
+	tp.width, tp.height = width, height
+	tp.sizeMu.Unlock()
+
+	// Drop the queued frames of the old size
+	for {
+		select {
+		case <-tp.frameQueue:
+		default:
+			return nil
+		}
+	}
+}
+
+func (tp *TestPattern) size() (width, height int) {
+	tp.sizeMu.Lock()
+	defer tp.sizeMu.Unlock()
+	return tp.width, tp.height
+}
+
+// PullFrame returns the next frame, waiting for it to be generated. It returns
+// nil once closed.
+func (tp *TestPattern) PullFrame() *image.RGBA {
+	select {
+	case img := <-tp.frameQueue:
+		return img
+	case <-tp.stopCh:
+		return nil
+	}
+}
+
+// Close stops generating frames.
+func (tp *TestPattern) Close() error {
+	close(tp.stopCh)
+	return nil
+}
+
+// TestPatternFrame returns frame n of the test pattern. The top two thirds hold
+// colour bars with blocks moving across them, followed by a band of scrolling
+// text and a frame counter. Motion is in pixels per frame, so it depends on the
+// frame number only; fps is shown in the counter.
+func TestPatternFrame(n uint64, fps, width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	if width <= 0 || height <= 0 {
+		return img
+	}
+	barsH := height * 2 / 3
+
+	// Colour bars
+	for i, c := range testPatternBars {
+		r := image.Rect(i*width/len(testPatternBars), 0, (i+1)*width/len(testPatternBars), barsH)
+		draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+	}
+
+	// Blocks moving across the bars at different speeds, bouncing at the edges
+	size := barsH / 6
+	if size < 4 {
+		size = 4
+	}
+	for i, c := range []color.RGBA{{0, 0, 0, 0xff}, {0xff, 0xff, 0xff, 0xff}, {0xff, 0x80, 0, 0xff}} {
+		x := bounce(int(n%(1<<31))*(i+1)*4, width-size)
+		y := barsH*(2*i+1)/6 - size/2
+		draw.Draw(img, image.Rect(x, y, x+size, y+size), image.NewUniform(c), image.Point{}, draw.Src)
+	}
+
+	// Scrolling text band
+	face := basicfont.Face7x13
+	lineH := face.Metrics().Height.Ceil()
+	band := image.Rect(0, barsH, width, barsH+lineH+8).Intersect(img.Bounds())
+	draw.Draw(img, band, image.Black, image.Point{}, draw.Src)
+	d := &font.Drawer{Dst: img, Src: image.White, Face: face}
+	textW := d.MeasureString(testPatternText).Ceil()
+	baseline := band.Min.Y + 4 + face.Metrics().Ascent.Ceil()
+	for x := -int(n * 2 % uint64(textW)); x < width; x += textW {
+		d.Dot = fixed.P(x, baseline)
+		d.DrawString(testPatternText)
+	}
+
+	// Grey ramp below, with the frame counter
+	rest := image.Rect(0, band.Max.Y, width, height)
+	for x := 0; x < width; x++ {
+		v := uint8(x * 0xff / width)
+		draw.Draw(img, image.Rect(x, rest.Min.Y, x+1, rest.Max.Y), image.NewUniform(color.RGBA{v, v, v, 0xff}), image.Point{}, draw.Src)
+	}
+	label := fmt.Sprintf("frame %08d  %dx%d  %d fps", n, width, height, fps)
+	box := image.Rect(8, rest.Min.Y+8, 8+d.MeasureString(label).Ceil()+8, rest.Min.Y+8+lineH+8).Intersect(img.Bounds())
+	draw.Draw(img, box, image.Black, image.Point{}, draw.Src)
+	d.Dot = fixed.P(box.Min.X+4, box.Min.Y+4+face.Metrics().Ascent.Ceil())
+	d.DrawString(label)
+	return img
+}
+
+// bounce maps a distance travelled to a position going back and forth over
+// [0, max].
+func bounce(dist, max int) int {
+	if max <= 0 {
+		return 0
+	}
+	dist %= 2 * max
+	if dist > max {
+		return 2*max - dist
+	}
+	return dist
+}
@@ -16,11 +16,29 @@ type Encoding interface {
 	HandleBuffer(w io.Writer, format *types.PixelFormat, img *image.RGBA)
 }
 
+// StatefulEncoding is implemented by encodings that carry state between rectangles,
+// such as a compression stream. The instances in DefaultEncodings are shared by
+// every connection, so each display asks for its own copy with NewInstance.
+type StatefulEncoding interface {
+	Encoding
+	// NewInstance should return a fresh encoder to be used by a single connection.
+	NewInstance() Encoding
+}
+
+// RectSplitter is implemented by encodings that limit the size of a single
+// rectangle. Larger areas are sent as several rectangles of the same update.
+type RectSplitter interface {
+	// SplitRect should return the rectangles, in order, that cover r.
+	SplitRect(r image.Rectangle) []image.Rectangle
+}
+
 // DefaultEncodings lists the encodings enabled by default on the server.
 var DefaultEncodings = []Encoding{
 	&RawEncoding{},
 	&TightEncoding{},
 	&TightPNGEncoding{},
+	&HextileEncoding{},
+	&ZRLEEncoding{},
 }
 
 // GetDefaults returns a slice of the default encoding handlers.
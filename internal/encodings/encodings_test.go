@@ -0,0 +1,177 @@
+package encodings
+
+import (
+	"encoding/binary"
+	"image"
+	"image/color"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/suutaku/go-vnc/internal/types"
+)
+
+// testFormats are the pixel formats encodings are checked against.
+var testFormats = []struct {
+	name   string
+	format *types.PixelFormat
+}{
+	{"32bpp little endian", &types.PixelFormat{BPP: 32, Depth: 24, TrueColour: 1,
+		RedMax: 255, GreenMax: 255, BlueMax: 255, RedShift: 16, GreenShift: 8, BlueShift: 0}},
+	{"32bpp big endian", &types.PixelFormat{BPP: 32, Depth: 24, BigEndian: 1, TrueColour: 1,
+		RedMax: 255, GreenMax: 255, BlueMax: 255, RedShift: 16, GreenShift: 8, BlueShift: 0}},
+	{"32bpp little endian high bytes", &types.PixelFormat{BPP: 32, Depth: 24, TrueColour: 1,
+		RedMax: 255, GreenMax: 255, BlueMax: 255, RedShift: 24, GreenShift: 16, BlueShift: 8}},
+	{"32bpp big endian high bytes", &types.PixelFormat{BPP: 32, Depth: 24, BigEndian: 1, TrueColour: 1,
+		RedMax: 255, GreenMax: 255, BlueMax: 255, RedShift: 24, GreenShift: 16, BlueShift: 8}},
+	{"16bpp little endian", &types.PixelFormat{BPP: 16, Depth: 16, TrueColour: 1,
+		RedMax: 31, GreenMax: 63, BlueMax: 31, RedShift: 11, GreenShift: 5, BlueShift: 0}},
+	{"16bpp big endian", &types.PixelFormat{BPP: 16, Depth: 16, BigEndian: 1, TrueColour: 1,
+		RedMax: 31, GreenMax: 63, BlueMax: 31, RedShift: 11, GreenShift: 5, BlueShift: 0}},
+	{"8bpp", &types.PixelFormat{BPP: 8, Depth: 8, TrueColour: 1,
+		RedMax: 7, GreenMax: 7, BlueMax: 3, RedShift: 0, GreenShift: 3, BlueShift: 6}},
+}
+
+// testSizes are rectangle sizes, most of them not multiples of any tile size.
+var testSizes = []image.Point{{1, 1}, {16, 16}, {64, 64}, {17, 33}, {65, 1}, {150, 100}, {330, 140}}
+
+// Kinds of tiles in test images, each meant for a different subencoding.
+const (
+	tileNoise = iota
+	tileSolid
+	tileTwoColours
+	tileFewColours
+	tileManyRuns
+	tilePaletteRuns
+)
+
+// tileKinds repeats kinds next to each other, so colours carried over from the
+// previous tile get used, and separates some by tiles after which they must
+// not be.
+var tileKinds = []int{
+	tileSolid, tileSolid, tileTwoColours, tileTwoColours, tileNoise,
+	tileTwoColours, tileFewColours, tileTwoColours, tileManyRuns, tilePaletteRuns,
+}
+
+// testImage returns an image of the given size, away from the origin, whose
+// tiles of tileSize pixels hold different kinds of content.
+func testImage(size image.Point, tileSize int, seed int64) *image.RGBA {
+	rnd := rand.New(rand.NewSource(seed))
+	random := func() color.RGBA {
+		return color.RGBA{uint8(rnd.Intn(256)), uint8(rnd.Intn(256)), uint8(rnd.Intn(256)), 255}
+	}
+	palette := make([]color.RGBA, 20)
+	for i := range palette {
+		palette[i] = random()
+	}
+	solid, fg := color.RGBA{10, 20, 30, 255}, color.RGBA{200, 100, 50, 255}
+
+	b := image.Rect(0, 0, size.X, size.Y).Add(image.Pt(7, 3))
+	img := image.NewRGBA(b)
+	cols := (size.X + tileSize - 1) / tileSize
+	for ty := b.Min.Y; ty < b.Max.Y; ty += tileSize {
+		for tx := b.Min.X; tx < b.Max.X; tx += tileSize {
+			tile := image.Rect(tx, ty, tx+tileSize, ty+tileSize).Intersect(b)
+			idx := (ty-b.Min.Y)/tileSize*cols + (tx-b.Min.X)/tileSize
+			runColours := make(map[int]color.RGBA)
+			for y := tile.Min.Y; y < tile.Max.Y; y++ {
+				for x := tile.Min.X; x < tile.Max.X; x++ {
+					lx, ly := x-tile.Min.X, y-tile.Min.Y
+					var c color.RGBA
+					switch tileKinds[idx%len(tileKinds)] {
+					case tileNoise:
+						c = random()
+					case tileSolid:
+						c = solid
+					case tileTwoColours:
+						c = solid
+						if lx%5 < 2 && ly%4 == 1 {
+							c = fg
+						}
+					case tileFewColours:
+						c = palette[(lx/3+ly/2)%4]
+					case tileManyRuns:
+						run := ly*4 + lx/(tileSize/4)
+						if _, ok := runColours[run]; !ok {
+							runColours[run] = random()
+						}
+						c = runColours[run]
+					case tilePaletteRuns:
+						c = palette[(lx/6+ly)%len(palette)]
+					}
+					img.SetRGBA(x, y, c)
+				}
+			}
+		}
+	}
+	return img
+}
+
+// wantPixels returns the pixels of the image in the given format, row by row.
+func wantPixels(img *image.RGBA, f *types.PixelFormat) []uint32 {
+	b := img.Bounds()
+	out := make([]uint32, 0, b.Dx()*b.Dy())
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			out = append(out, rgbaPixel(img, x, y, f))
+		}
+	}
+	return out
+}
+
+// checkPixels compares decoded pixels with the image.
+func checkPixels(t *testing.T, got []uint32, img *image.RGBA, f *types.PixelFormat) {
+	t.Helper()
+	want := wantPixels(img, f)
+	w := img.Bounds().Dx()
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("pixel %d,%d decoded as %#x, want %#x", i%w, i/w, got[i], want[i])
+		}
+	}
+}
+
+// testDecoder reads what encoders write, failing the test on errors.
+type testDecoder struct {
+	t *testing.T
+	r io.Reader
+}
+
+func (d *testDecoder) bytes(n int) []byte {
+	d.t.Helper()
+	b := make([]byte, n)
+	if _, err := io.ReadFull(d.r, b); err != nil {
+		d.t.Fatal(err)
+	}
+	return b
+}
+
+func (d *testDecoder) byte() int {
+	d.t.Helper()
+	return int(d.bytes(1)[0])
+}
+
+// pixel reads a PIXEL of the format.
+func (d *testDecoder) pixel(f *types.PixelFormat) uint32 {
+	d.t.Helper()
+	var order binary.ByteOrder = binary.LittleEndian
+	if f.BigEndian != 0 {
+		order = binary.BigEndian
+	}
+	switch f.BPP {
+	case 32:
+		return order.Uint32(d.bytes(4))
+	case 16:
+		return uint32(order.Uint16(d.bytes(2)))
+	}
+	return uint32(d.byte())
+}
+
+// fillRect sets the pixels of r, relative to a w pixels wide area.
+func fillRect(px []uint32, w int, r image.Rectangle, p uint32) {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			px[y*w+x] = p
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package encodings
+
+import (
+	"bytes"
+	"image"
+	"io"
+
+	"github.com/suutaku/go-vnc/internal/types"
+)
+
+// Hextile subencoding mask bits.
+const (
+	hextileRaw                 = 1
+	hextileBackgroundSpecified = 2
+	hextileForegroundSpecified = 4
+	hextileAnySubrects         = 8
+	hextileSubrectsColoured    = 16
+
+	hextileTileSize    = 16
+	hextileMaxSubrects = 255
+)
+
+// HextileEncoding implements an Encoding intercace using Hextile encoding.
+type HextileEncoding struct{}
+
+// Code returns the code
+func (h *HextileEncoding) Code() int32 { return 5 }
+
+// HandleBuffer handles an image sample.
+func (h *HextileEncoding) HandleBuffer(w io.Writer, f *types.PixelFormat, img *image.RGBA) {
+	buf := new(bytes.Buffer)
+	enc := &hextileWriter{buf: buf, format: f, bpp: bytesPerPixel(f)}
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y += hextileTileSize {
+		for x := b.Min.X; x < b.Max.X; x += hextileTileSize {
+			enc.writeTile(img, image.Rect(x, y, x+hextileTileSize, y+hextileTileSize).Intersect(b))
+		}
+	}
+	w.Write(buf.Bytes())
+}
+
+type hextileSubrect struct {
+	x, y, w, h int
+	colour     uint32
+}
+
+// hextileWriter keeps the background and foreground colours that carry over
+// from one tile to the next within a single rectangle.
+type hextileWriter struct {
+	buf    *bytes.Buffer
+	format *types.PixelFormat
+	bpp    int
+
+	bg, fg           uint32
+	bgValid, fgValid bool
+}
+
+func (e *hextileWriter) writeTile(img *image.RGBA, r image.Rectangle) {
+	w, h := r.Dx(), r.Dy()
+	px := make([]uint32, 0, w*h)
+	counts := make(map[uint32]int)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			p := rgbaPixel(img, x, y, e.format)
+			px = append(px, p)
+			counts[p]++
+		}
+	}
+
+	// The most common colour makes the best background.
+	var bg uint32
+	var most int
+	for p, n := range counts {
+		if n > most {
+			bg, most = p, n
+		}
+	}
+
+	var mask uint8
+	if !e.bgValid || e.bg != bg {
+		mask |= hextileBackgroundSpecified
+	}
+	if len(counts) == 1 {
+		e.buf.WriteByte(mask)
+		if mask&hextileBackgroundSpecified != 0 {
+			e.writePixel(bg)
+		}
+		e.bg, e.bgValid = bg, true
+		return
+	}
+
+	subrects := hextileSubrects(px, w, h, bg)
+	coloured := len(counts) > 2
+	mask |= hextileAnySubrects
+	subrectSize := 2
+	if coloured {
+		mask |= hextileSubrectsColoured
+		subrectSize += e.bpp
+	} else if !e.fgValid || e.fg != subrects[0].colour {
+		mask |= hextileForegroundSpecified
+	}
+
+	size := 2 + len(subrects)*subrectSize
+	if mask&hextileBackgroundSpecified != 0 {
+		size += e.bpp
+	}
+	if mask&hextileForegroundSpecified != 0 {
+		size += e.bpp
+	}
+	if len(subrects) > hextileMaxSubrects || size >= 1+w*h*e.bpp {
+		e.writeRawTile(px)
+		return
+	}
+
+	e.buf.WriteByte(mask)
+	if mask&hextileBackgroundSpecified != 0 {
+		e.writePixel(bg)
+	}
+	if mask&hextileForegroundSpecified != 0 {
+		e.writePixel(subrects[0].colour)
+	}
+	e.buf.WriteByte(uint8(len(subrects)))
+	for _, s := range subrects {
+		if coloured {
+			e.writePixel(s.colour)
+		}
+		e.buf.WriteByte(uint8(s.x<<4 | s.y))
+		e.buf.WriteByte(uint8((s.w-1)<<4 | (s.h - 1)))
+	}
+
+	e.bg, e.bgValid = bg, true
+	if coloured {
+		// The foreground is undefined after a tile with coloured subrects.
+		e.fgValid = false
+	} else {
+		e.fg, e.fgValid = subrects[0].colour, true
+	}
+}
+
+func (e *hextileWriter) writeRawTile(px []uint32) {
+	e.buf.WriteByte(hextileRaw)
+	for _, p := range px {
+		e.writePixel(p)
+	}
+	// Background and foreground are not carried over a raw tile.
+	e.bgValid, e.fgValid = false, false
+}
+
+func (e *hextileWriter) writePixel(p uint32) {
+	var tmp [4]byte
+	putPixel(tmp[:], p, e.format)
+	e.buf.Write(tmp[:e.bpp])
+}
+
+// hextileSubrects greedily covers every pixel that differs from the background
+// with solid rectangles, growing each one right and then down.
+func hextileSubrects(px []uint32, w, h int, bg uint32) []hextileSubrect {
+	covered := make([]bool, len(px))
+	out := make([]hextileSubrect, 0)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := y*w + x
+			if covered[i] || px[i] == bg {
+				continue
+			}
+			c := px[i]
+			sw := 1
+			for x+sw < w && px[i+sw] == c && !covered[i+sw] {
+				sw++
+			}
+			sh := 1
+		grow:
+			for y+sh < h {
+				row := (y+sh)*w + x
+				for k := 0; k < sw; k++ {
+					if px[row+k] != c || covered[row+k] {
+						break grow
+					}
+				}
+				sh++
+			}
+			for dy := 0; dy < sh; dy++ {
+				for dx := 0; dx < sw; dx++ {
+					covered[(y+dy)*w+x+dx] = true
+				}
+			}
+			out = append(out, hextileSubrect{x: x, y: y, w: sw, h: sh, colour: c})
+		}
+	}
+	return out
+}
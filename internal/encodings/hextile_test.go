@@ -0,0 +1,112 @@
+package encodings
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"testing"
+
+	"github.com/suutaku/go-vnc/internal/types"
+)
+
+// hextileDecoder decodes Hextile rectangles. It fails on tiles relying on a
+// background or foreground colour that did not carry over from the tile
+// before.
+type hextileDecoder struct {
+	testDecoder
+	format *types.PixelFormat
+	// Subencodings and carried over colours seen so far.
+	seen map[string]bool
+}
+
+func (d *hextileDecoder) rect(w, h int) []uint32 {
+	d.t.Helper()
+	out := make([]uint32, w*h)
+	var bg, fg uint32
+	// No colour carries over into a rectangle.
+	bgValid, fgValid := false, false
+	for ty := 0; ty < h; ty += 16 {
+		for tx := 0; tx < w; tx += 16 {
+			tile := image.Rect(tx, ty, tx+16, ty+16).Intersect(image.Rect(0, 0, w, h))
+			mask := d.byte()
+			if mask&hextileRaw != 0 {
+				d.seen["raw"] = true
+				for y := tile.Min.Y; y < tile.Max.Y; y++ {
+					for x := tile.Min.X; x < tile.Max.X; x++ {
+						out[y*w+x] = d.pixel(d.format)
+					}
+				}
+				// The colours are undefined after a raw tile.
+				bgValid, fgValid = false, false
+				continue
+			}
+			if mask&hextileBackgroundSpecified != 0 {
+				bg, bgValid = d.pixel(d.format), true
+			} else if !bgValid {
+				d.t.Fatalf("tile at %d,%d uses an undefined background", tx, ty)
+			} else {
+				d.seen["background carried over"] = true
+			}
+			fillRect(out, w, tile, bg)
+			if mask&hextileForegroundSpecified != 0 {
+				fg, fgValid = d.pixel(d.format), true
+			}
+			if mask&hextileAnySubrects == 0 {
+				d.seen["solid"] = true
+				continue
+			}
+			coloured := mask&hextileSubrectsColoured != 0
+			if coloured {
+				d.seen["coloured subrects"] = true
+			} else {
+				if !fgValid {
+					d.t.Fatalf("tile at %d,%d uses an undefined foreground", tx, ty)
+				}
+				if mask&hextileForegroundSpecified == 0 {
+					d.seen["foreground carried over"] = true
+				}
+				d.seen["subrects"] = true
+			}
+			n := d.byte()
+			for i := 0; i < n; i++ {
+				p := fg
+				if coloured {
+					p = d.pixel(d.format)
+				}
+				xy, wh := d.byte(), d.byte()
+				sub := image.Rect(xy>>4, xy&15, xy>>4+wh>>4+1, xy&15+wh&15+1).Add(tile.Min)
+				if !sub.In(tile) {
+					d.t.Fatalf("subrect %v outside tile %v", sub, tile)
+				}
+				fillRect(out, w, sub, p)
+			}
+			if coloured {
+				fgValid = false
+			}
+		}
+	}
+	return out
+}
+
+func TestHextileRoundTrip(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, tf := range testFormats {
+		for i, size := range testSizes {
+			t.Run(fmt.Sprint(tf.name, size), func(t *testing.T) {
+				img := testImage(size, 16, int64(i))
+				var rect bytes.Buffer
+				(&HextileEncoding{}).HandleBuffer(&rect, tf.format, img)
+				d := &hextileDecoder{testDecoder: testDecoder{t: t, r: &rect}, format: tf.format, seen: seen}
+				checkPixels(t, d.rect(size.X, size.Y), img, tf.format)
+				if rect.Len() != 0 {
+					t.Errorf("%d bytes left after the rectangle", rect.Len())
+				}
+			})
+		}
+	}
+	for _, s := range []string{"raw", "solid", "subrects", "coloured subrects", "background carried over", "foreground carried over"} {
+		if !seen[s] {
+			t.Errorf("no tile used %s", s)
+		}
+	}
+}
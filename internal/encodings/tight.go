@@ -2,10 +2,10 @@ package encodings
 
 import (
 	"bytes"
+	"compress/zlib"
 	"image"
 	"io"
 	"log"
-	"strconv"
 
 	"github.com/pixiv/go-libjpeg/jpeg"
 
@@ -13,33 +13,340 @@ import (
 	"github.com/suutaku/go-vnc/internal/utils"
 )
 
+// Tight compression control values.
+const (
+	tightFill           = 0x80
+	tightJPEG           = 0x90
+	tightPNG            = 0xA0
+	tightExplicitFilter = 0x40
+
+	tightFilterCopy     = 0
+	tightFilterPalette  = 1
+	tightFilterGradient = 2
+)
+
+// Zlib stream ids, following the layout used by the TightVNC server.
+const (
+	tightStreamFullColour = 0
+	tightStreamMono       = 1
+	tightStreamIndexed    = 2
+	tightStreamGradient   = 3
+)
+
+// Limits for a single Tight rectangle and the heuristics picking a filter.
+const (
+	tightMaxRectWidth    = 2048
+	tightMaxRectSize     = 65536
+	tightMaxPalette      = 256
+	tightMinToCompress   = 12
+	tightMinJPEGArea     = 1024
+	tightSmoothThreshold = 20
+)
+
 // TightEncoding implements an Encoding intercace using Tight encoding.
-type TightEncoding struct{}
+//
+// Each connection owns four zlib streams, so the encoder must be instantiated per
+// connection. See NewInstance.
+type TightEncoding struct {
+	streams     [4]*tightStream
+	quality     int // JPEG quality, zero disables JPEG
+	subsampling int
+	level       int // zlib compression level
+}
+
+type tightStream struct {
+	buf bytes.Buffer
+	zw  *zlib.Writer
+	// reset is set when the client has to start a new inflate stream for this id.
+	reset bool
+}
 
 // Code returns the code
 func (t *TightEncoding) Code() int32 { return 7 }
 
+// NewInstance returns a Tight encoder with its own set of zlib streams.
+func (t *TightEncoding) NewInstance() Encoding {
+	return &TightEncoding{subsampling: Subsample4X, level: zlib.DefaultCompression}
+}
+
+// Tune applies the client's JPEG quality and compression level. JPEG is only
+// used once the client asked for a quality. A new compression level restarts
+// the zlib streams, which the client is told about through the reset bits.
+func (t *TightEncoding) Tune(tuning *Tuning) {
+	t.quality, t.subsampling = tuning.JPEG()
+	if level := tuning.ZlibLevel(); level != t.level {
+		t.level = level
+		for _, s := range t.streams {
+			if s != nil {
+				s.zw = nil
+			}
+		}
+	}
+}
+
+// SplitRect splits r into rectangles within the limits of the Tight protocol.
+func (t *TightEncoding) SplitRect(r image.Rectangle) []image.Rectangle {
+	return splitRect(r, tightMaxRectWidth, tightMaxRectSize)
+}
+
 // HandleBuffer handles an image sample.
 func (t *TightEncoding) HandleBuffer(w io.Writer, f *types.PixelFormat, img *image.RGBA) {
+	tp := newTPixel(f)
+	b := img.Bounds()
 
-	compressed := new(bytes.Buffer)
+	palette, index := tightPalette(img, f, tightMaxPalette)
+	switch {
+	case len(palette) == 1:
+		utils.Write(w, uint8(tightFill))
+		utils.Write(w, tp.bytes(palette[0]))
+		return
+	case len(palette) > 0:
+		t.writePalette(w, tp, img, palette, index)
+		return
+	}
 
-	err := jpeg.Encode(compressed, img, &jpeg.EncoderOptions{Quality: 80, OptimizeCoding: true, DCTMethod: jpeg.DCTFloat})
+	smooth := b.Dx() >= 8 && b.Dy() >= 8 && gradientError(img) < tightSmoothThreshold
+	if smooth && t.quality > 0 && f.BPP >= 16 && b.Dx()*b.Dy() >= tightMinJPEGArea {
+		if err := t.writeJPEG(w, img); err == nil {
+			return
+		}
+	}
+	if smooth && tp.size == 3 {
+		t.writeBasic(w, tightStreamGradient, tightFilterGradient, nil, gradientFilter(img))
+		return
+	}
+
+	data := make([]byte, 0, b.Dx()*b.Dy()*tp.size)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			data = append(data, tp.bytes(rgbaPixel(img, x, y, f))...)
+		}
+	}
+	t.writeBasic(w, tightStreamFullColour, -1, nil, data)
+}
+
+func (t *TightEncoding) writePalette(w io.Writer, tp tpixel, img *image.RGBA, palette []uint32, index map[uint32]uint8) {
+	b := img.Bounds()
+	header := []byte{uint8(len(palette) - 1)}
+	for _, p := range palette {
+		header = append(header, tp.bytes(p)...)
+	}
+
+	data := make([]byte, 0, b.Dx()*b.Dy())
+	if len(palette) == 2 {
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			var cur uint8
+			var used uint
+			for x := b.Min.X; x < b.Max.X; x++ {
+				cur = cur<<1 | index[rgbaPixel(img, x, y, tp.format)]
+				if used++; used == 8 {
+					data = append(data, cur)
+					cur, used = 0, 0
+				}
+			}
+			if used > 0 {
+				data = append(data, cur<<(8-used))
+			}
+		}
+		t.writeBasic(w, tightStreamMono, tightFilterPalette, header, data)
+		return
+	}
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			data = append(data, index[rgbaPixel(img, x, y, tp.format)])
+		}
+	}
+	t.writeBasic(w, tightStreamIndexed, tightFilterPalette, header, data)
+}
+
+func (t *TightEncoding) writeJPEG(w io.Writer, img *image.RGBA) error {
+	compressed := new(bytes.Buffer)
+	src := subsampledImage(img, t.subsampling)
+	err := jpeg.Encode(compressed, src, &jpeg.EncoderOptions{Quality: t.quality, OptimizeCoding: true, DCTMethod: jpeg.DCTFloat})
 	if err != nil {
 		log.Println("[tight-jpeg] Could not encode image frame to jpeg")
-		return
+		return err
 	}
 
 	buf := compressed.Bytes()
 
-	i, _ := strconv.ParseInt("10010000", 2, 64) // JPEG encoding
-	utils.Write(w, uint8(i))
+	utils.Write(w, uint8(tightJPEG))
 
 	// Buffer length
 	utils.Write(w, computeTightLength(len(buf)))
 
 	// Buffer contents
 	utils.Write(w, buf)
+	return nil
+}
+
+// writeBasic writes a rectangle using basic compression on the given zlib stream.
+// A negative filter omits the filter byte, which implies the copy filter.
+func (t *TightEncoding) writeBasic(w io.Writer, stream int, filter int, header, data []byte) {
+	ctl := uint8(stream << 4)
+	if filter >= 0 {
+		ctl |= tightExplicitFilter
+	}
+
+	// Tiny payloads are sent as-is and never touch the zlib stream.
+	if len(data) < tightMinToCompress {
+		utils.Write(w, ctl)
+		if filter >= 0 {
+			utils.Write(w, uint8(filter))
+		}
+		utils.Write(w, header)
+		utils.Write(w, data)
+		return
+	}
+
+	s := t.stream(stream)
+	if _, err := s.zw.Write(data); err != nil {
+		log.Println("[tight] Could not compress rectangle:", err)
+		return
+	}
+	if err := s.zw.Flush(); err != nil {
+		log.Println("[tight] Could not flush zlib stream:", err)
+		return
+	}
+	if s.reset {
+		ctl |= 1 << uint(stream)
+		s.reset = false
+	}
+
+	utils.Write(w, ctl)
+	if filter >= 0 {
+		utils.Write(w, uint8(filter))
+	}
+	utils.Write(w, header)
+	utils.Write(w, computeTightLength(s.buf.Len()))
+	utils.Write(w, s.buf.Bytes())
+	s.buf.Reset()
+}
+
+// stream returns the zlib stream with the given id, starting it if needed.
+func (t *TightEncoding) stream(id int) *tightStream {
+	s := t.streams[id]
+	if s == nil {
+		s = &tightStream{}
+		t.streams[id] = s
+	}
+	if s.zw == nil {
+		s.buf.Reset()
+		s.zw, _ = zlib.NewWriterLevel(&s.buf, t.level)
+		s.reset = true
+	}
+	return s
+}
+
+// tightPalette returns the distinct pixel values of img in the given format, in
+// order of appearance, with their palette indexes. It returns nil if there are
+// more than max colours.
+func tightPalette(img *image.RGBA, f *types.PixelFormat, max int) ([]uint32, map[uint32]uint8) {
+	b := img.Bounds()
+	palette := make([]uint32, 0)
+	index := make(map[uint32]uint8)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			p := rgbaPixel(img, x, y, f)
+			if _, ok := index[p]; ok {
+				continue
+			}
+			if len(palette) == max {
+				return nil, nil
+			}
+			index[p] = uint8(len(palette))
+			palette = append(palette, p)
+		}
+	}
+	return palette, index
+}
+
+// gradientError returns the mean per-channel error of the gradient predictor over
+// img. Photographic content predicts well, text and UI elements do not.
+func gradientError(img *image.RGBA) int {
+	b := img.Bounds()
+	var sum, n int
+	for y := b.Min.Y + 1; y < b.Max.Y; y++ {
+		for x := b.Min.X + 1; x < b.Max.X; x++ {
+			o := img.PixOffset(x, y)
+			left := o - 4
+			up := o - img.Stride
+			upLeft := up - 4
+			for c := 0; c < 3; c++ {
+				pred := int(img.Pix[left+c]) + int(img.Pix[up+c]) - int(img.Pix[upLeft+c])
+				pred = clampByte(pred)
+				diff := int(img.Pix[o+c]) - pred
+				if diff < 0 {
+					diff = -diff
+				}
+				sum += diff
+			}
+			n += 3
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / n
+}
+
+// gradientFilter applies the Tight gradient filter to img, producing three bytes
+// per pixel. Neighbours outside of the rectangle are treated as zero.
+func gradientFilter(img *image.RGBA) []byte {
+	b := img.Bounds()
+	out := make([]byte, 0, b.Dx()*b.Dy()*3)
+	at := func(x, y, c int) int {
+		if x < b.Min.X || y < b.Min.Y {
+			return 0
+		}
+		return int(img.Pix[img.PixOffset(x, y)+c])
+	}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			for c := 0; c < 3; c++ {
+				pred := clampByte(at(x-1, y, c) + at(x, y-1, c) - at(x-1, y-1, c))
+				out = append(out, uint8(at(x, y, c)-pred))
+			}
+		}
+	}
+	return out
+}
+
+func clampByte(v int) int {
+	switch {
+	case v < 0:
+		return 0
+	case v > 0xff:
+		return 0xff
+	}
+	return v
+}
+
+// tpixel describes how a pixel is written as a Tight TPIXEL. For 32bpp true
+// colour formats with a depth of 24 a TPIXEL is three bytes of red, green and
+// blue, otherwise it is the same as a PIXEL.
+type tpixel struct {
+	format *types.PixelFormat
+	size   int
+}
+
+func newTPixel(f *types.PixelFormat) tpixel {
+	if f.TrueColour != 0 && f.BPP == 32 && f.Depth == 24 &&
+		f.RedMax == 0xff && f.GreenMax == 0xff && f.BlueMax == 0xff {
+		return tpixel{format: f, size: 3}
+	}
+	return tpixel{format: f, size: bytesPerPixel(f)}
+}
+
+func (tp tpixel) bytes(p uint32) []byte {
+	if tp.size == 3 {
+		f := tp.format
+		return []byte{uint8(p >> f.RedShift), uint8(p >> f.GreenShift), uint8(p >> f.BlueShift)}
+	}
+	out := make([]byte, tp.size)
+	putPixel(out, p, tp.format)
+	return out
 }
 
 func computeTightLength(compressedLen int) (b []byte) {
@@ -6,23 +6,31 @@ import (
 	"image/png"
 	"io"
 	"log"
-	"strconv"
 
 	"github.com/suutaku/go-vnc/internal/types"
 	"github.com/suutaku/go-vnc/internal/utils"
 )
 
 // TightPNGEncoding implements an Encoding intercace using Tight encoding.
-type TightPNGEncoding struct{}
+type TightPNGEncoding struct {
+	level png.CompressionLevel
+}
 
 // Code returns the code
 func (t *TightPNGEncoding) Code() int32 { return -260 }
 
+// NewInstance returns a TightPNG encoder that can be tuned for a single connection.
+func (t *TightPNGEncoding) NewInstance() Encoding { return &TightPNGEncoding{} }
+
+// Tune applies the client's compression level to the PNG encoder.
+func (t *TightPNGEncoding) Tune(tuning *Tuning) { t.level = tuning.PNGLevel() }
+
 // HandleBuffer handles an image sample.
 func (t *TightPNGEncoding) HandleBuffer(w io.Writer, f *types.PixelFormat, img *image.RGBA) {
 	compressed := new(bytes.Buffer)
 
-	err := png.Encode(compressed, img)
+	enc := &png.Encoder{CompressionLevel: t.level}
+	err := enc.Encode(compressed, img)
 	if err != nil {
 		log.Println("[tight-png] Could not encode image frame to png")
 		return
@@ -30,8 +38,7 @@ func (t *TightPNGEncoding) HandleBuffer(w io.Writer, f *types.PixelFormat, img *
 
 	buf := compressed.Bytes()
 
-	i, _ := strconv.ParseInt("01010000", 2, 64) // PNG encoding
-	utils.Write(w, uint8(i))
+	utils.Write(w, uint8(tightPNG))
 
 	// Buffer length
 	utils.Write(w, computeTightLength(len(buf)))
@@ -0,0 +1,252 @@
+package encodings
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/suutaku/go-vnc/internal/types"
+)
+
+// tightDecoder decodes Tight rectangles without JPEG. It keeps the four zlib
+// streams of a connection, which are only started when the server resets them.
+type tightDecoder struct {
+	testDecoder
+	format  *types.PixelFormat
+	streams [4]io.Reader
+	inputs  [4]*bytes.Buffer
+	// Streams used, and how many times one was reset.
+	used   map[int]bool
+	resets int
+	// Compression methods seen so far.
+	seen map[string]bool
+}
+
+func (d *tightDecoder) length() int {
+	d.t.Helper()
+	b := d.byte()
+	n := b & 0x7f
+	if b&0x80 != 0 {
+		b = d.byte()
+		n |= b & 0x7f << 7
+		if b&0x80 != 0 {
+			n |= d.byte() << 14
+		}
+	}
+	return n
+}
+
+// tpixel reads a TPIXEL, which is the red, green and blue bytes of 32bpp
+// formats with a depth of 24 and 8 bits per colour.
+func (d *tightDecoder) tpixel() uint32 {
+	d.t.Helper()
+	f := d.format
+	if f.TrueColour != 0 && f.BPP == 32 && f.Depth == 24 && f.RedMax == 255 && f.GreenMax == 255 && f.BlueMax == 255 {
+		b := d.bytes(3)
+		return uint32(b[0])<<f.RedShift | uint32(b[1])<<f.GreenShift | uint32(b[2])<<f.BlueShift
+	}
+	return d.pixel(f)
+}
+
+func (d *tightDecoder) tpixelSize() int {
+	f := d.format
+	if f.TrueColour != 0 && f.BPP == 32 && f.Depth == 24 && f.RedMax == 255 && f.GreenMax == 255 && f.BlueMax == 255 {
+		return 3
+	}
+	return bytesPerPixel(f)
+}
+
+// data reads size bytes of filtered data from the given stream.
+func (d *tightDecoder) data(stream, size int) []byte {
+	d.t.Helper()
+	if size < tightMinToCompress {
+		d.seen["uncompressed"] = true
+		return d.bytes(size)
+	}
+	d.used[stream] = true
+	compressed := d.bytes(d.length())
+	if d.inputs[stream] == nil {
+		d.t.Fatalf("stream %d used before the server reset it", stream)
+	}
+	d.inputs[stream].Write(compressed)
+	if d.streams[stream] == nil {
+		zr, err := zlib.NewReader(d.inputs[stream])
+		if err != nil {
+			d.t.Fatalf("starting stream %d: %v", stream, err)
+		}
+		d.streams[stream] = zr
+	}
+	out := make([]byte, size)
+	if _, err := io.ReadFull(d.streams[stream], out); err != nil {
+		d.t.Fatalf("reading stream %d: %v", stream, err)
+	}
+	return out
+}
+
+func (d *tightDecoder) rect(w, h int) []uint32 {
+	d.t.Helper()
+	out := make([]uint32, w*h)
+	ctl := d.byte()
+	for i := 0; i < 4; i++ {
+		if ctl&(1<<i) != 0 {
+			d.seen["reset"] = true
+			d.resets++
+			d.streams[i], d.inputs[i] = nil, new(bytes.Buffer)
+		}
+	}
+	switch ctl & 0xf0 {
+	case tightFill:
+		d.seen["fill"] = true
+		fillRect(out, w, image.Rect(0, 0, w, h), d.tpixel())
+		return out
+	case tightJPEG, tightPNG:
+		d.t.Fatalf("unexpected compression control %#x", ctl)
+	}
+	stream := int(ctl >> 4 & 3)
+	filter := tightFilterCopy
+	if ctl&tightExplicitFilter != 0 {
+		filter = d.byte()
+	}
+
+	switch filter {
+	case tightFilterCopy:
+		d.seen["copy"] = true
+		size := d.tpixelSize()
+		r := &tightDecoder{testDecoder: testDecoder{t: d.t, r: bytes.NewReader(d.data(stream, w*h*size))}, format: d.format}
+		for i := range out {
+			out[i] = r.tpixel()
+		}
+	case tightFilterPalette:
+		palette := make([]uint32, d.byte()+1)
+		for i := range palette {
+			palette[i] = d.tpixel()
+		}
+		if len(palette) == 2 {
+			d.seen["mono"] = true
+			stride := (w + 7) / 8
+			data := d.data(stream, stride*h)
+			for y := 0; y < h; y++ {
+				for x := 0; x < w; x++ {
+					out[y*w+x] = palette[data[y*stride+x/8]>>(7-x%8)&1]
+				}
+			}
+		} else {
+			d.seen["indexed"] = true
+			for i, idx := range d.data(stream, w*h) {
+				if int(idx) >= len(palette) {
+					d.t.Fatalf("index %d outside palette of %d", idx, len(palette))
+				}
+				out[i] = palette[idx]
+			}
+		}
+	case tightFilterGradient:
+		d.seen["gradient"] = true
+		if d.tpixelSize() != 3 {
+			d.t.Fatal("gradient filter on pixels that are not 24-bit")
+		}
+		data := d.data(stream, w*h*3)
+		rgb := make([]int, w*h*3)
+		at := func(x, y, c int) int {
+			if x < 0 || y < 0 {
+				return 0
+			}
+			return rgb[(y*w+x)*3+c]
+		}
+		f := d.format
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				for c := 0; c < 3; c++ {
+					pred := clampByte(at(x-1, y, c) + at(x, y-1, c) - at(x-1, y-1, c))
+					rgb[(y*w+x)*3+c] = (pred + int(data[(y*w+x)*3+c])) & 0xff
+				}
+				i := (y*w + x) * 3
+				out[y*w+x] = uint32(rgb[i])<<f.RedShift | uint32(rgb[i+1])<<f.GreenShift | uint32(rgb[i+2])<<f.BlueShift
+			}
+		}
+	default:
+		d.t.Fatalf("unknown filter %d", filter)
+	}
+	return out
+}
+
+// Kinds of Tight test images, each meant for a different compression method.
+var tightImageKinds = []struct {
+	name   string
+	colour func(rnd *rand.Rand, x, y int) color.RGBA
+}{
+	{"solid", func(rnd *rand.Rand, x, y int) color.RGBA { return color.RGBA{10, 20, 30, 255} }},
+	{"two colours", func(rnd *rand.Rand, x, y int) color.RGBA {
+		if (x*y)%3 == 0 {
+			return color.RGBA{255, 255, 255, 255}
+		}
+		return color.RGBA{0, 0, 0, 255}
+	}},
+	{"few colours", func(rnd *rand.Rand, x, y int) color.RGBA {
+		return color.RGBA{uint8(x % 10 * 20), uint8(y % 7 * 30), 0, 255}
+	}},
+	{"gradient", func(rnd *rand.Rand, x, y int) color.RGBA {
+		return color.RGBA{uint8(x * 3), uint8(y * 2), uint8(x + y), 255}
+	}},
+	{"noise", func(rnd *rand.Rand, x, y int) color.RGBA {
+		return color.RGBA{uint8(rnd.Intn(256)), uint8(rnd.Intn(256)), uint8(rnd.Intn(256)), 255}
+	}},
+}
+
+func tightImage(size image.Point, colour func(rnd *rand.Rand, x, y int) color.RGBA) *image.RGBA {
+	rnd := rand.New(rand.NewSource(1))
+	img := image.NewRGBA(image.Rect(0, 0, size.X, size.Y).Add(image.Pt(5, 9)))
+	for y := 0; y < size.Y; y++ {
+		for x := 0; x < size.X; x++ {
+			img.SetRGBA(img.Rect.Min.X+x, img.Rect.Min.Y+y, colour(rnd, x, y))
+		}
+	}
+	return img
+}
+
+func TestTightRoundTrip(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, tf := range testFormats {
+		t.Run(tf.name, func(t *testing.T) {
+			enc := (&TightEncoding{}).NewInstance()
+			var stream bytes.Buffer
+			var images []*image.RGBA
+			for _, level := range []int{-1, 1, 9} {
+				// A new compression level restarts every stream, which the
+				// client has to be told about.
+				enc.(Tunable).Tune(&Tuning{QualityLevel: -1, FineQuality: -1, Subsampling: -1, CompressLevel: level})
+				for _, kind := range tightImageKinds {
+					for _, size := range []image.Point{{3, 1}, {17, 33}, {100, 80}} {
+						img := tightImage(size, kind.colour)
+						images = append(images, img)
+						enc.HandleBuffer(&stream, tf.format, img)
+					}
+				}
+			}
+
+			d := &tightDecoder{testDecoder: testDecoder{t: t, r: &stream}, format: tf.format, used: make(map[int]bool), seen: seen}
+			for _, img := range images {
+				size := img.Bounds().Size()
+				t.Run(fmt.Sprint(size), func(t *testing.T) {
+					d.t = t
+					checkPixels(t, d.rect(size.X, size.Y), img, tf.format)
+				})
+			}
+			if stream.Len() != 0 {
+				t.Errorf("%d bytes left after the rectangles", stream.Len())
+			}
+			if d.resets <= len(d.used) {
+				t.Errorf("%d resets of %d streams, they were not restarted for new compression levels", d.resets, len(d.used))
+			}
+		})
+	}
+	for _, s := range []string{"fill", "copy", "mono", "indexed", "gradient", "uncompressed", "reset"} {
+		if !seen[s] {
+			t.Errorf("no rectangle used %s", s)
+		}
+	}
+}
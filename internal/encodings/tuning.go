@@ -0,0 +1,167 @@
+package encodings
+
+import (
+	"compress/zlib"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// Pseudo-encoding ranges used by clients to tune the encoders.
+const (
+	jpegQualityLevel0   = -32  // -32..-23, quality level 0-9
+	compressLevel0      = -256 // -256..-247, compression level 0-9
+	fineQualityLevel0   = -512 // -512..-412, TurboVNC JPEG quality 0-100
+	subsamplingLevel0   = -768 // -768..-763, TurboVNC chroma subsampling
+	subsamplingLevelMax = -763
+)
+
+// Chroma subsampling modes, in the order of their TurboVNC pseudo-encodings.
+const (
+	Subsample1X = iota
+	Subsample4X
+	Subsample2X
+	SubsampleGray
+	Subsample8X
+	Subsample16X
+)
+
+// Tuning holds the encoder preferences a client expressed through its
+// pseudo-encodings. Fields are -1 when the client did not send them.
+type Tuning struct {
+	QualityLevel  int // 0-9
+	FineQuality   int // 0-100
+	Subsampling   int // one of the Subsample constants
+	CompressLevel int // 0-9
+}
+
+// Tunable is implemented by encodings that honour the client's quality and
+// compression preferences. Tune is called every time the client sends a new
+// set of encodings.
+type Tunable interface {
+	Tune(t *Tuning)
+}
+
+// tightQualityLevels maps quality levels 0-9 to a JPEG quality and chroma
+// subsampling, the same way TigerVNC does.
+var tightQualityLevels = [10]struct{ quality, subsampling int }{
+	{15, Subsample4X}, {29, Subsample4X}, {41, Subsample4X},
+	{42, Subsample2X}, {62, Subsample2X}, {77, Subsample2X},
+	{79, Subsample1X}, {86, Subsample1X}, {92, Subsample1X}, {100, Subsample1X},
+}
+
+// ParseTuning reads the tuning pseudo-encodings out of the given list. When a
+// range is sent more than once the first occurrence wins, as the client lists
+// its encodings in order of preference.
+func ParseTuning(pseudo []int32) *Tuning {
+	t := &Tuning{QualityLevel: -1, FineQuality: -1, Subsampling: -1, CompressLevel: -1}
+	for _, e := range pseudo {
+		switch {
+		case e >= jpegQualityLevel0 && e <= jpegQualityLevel0+9:
+			if t.QualityLevel == -1 {
+				t.QualityLevel = int(e - jpegQualityLevel0)
+			}
+		case e >= compressLevel0 && e <= compressLevel0+9:
+			if t.CompressLevel == -1 {
+				t.CompressLevel = int(e - compressLevel0)
+			}
+		case e >= fineQualityLevel0 && e <= fineQualityLevel0+100:
+			if t.FineQuality == -1 {
+				t.FineQuality = int(e - fineQualityLevel0)
+			}
+		case e >= subsamplingLevel0 && e <= subsamplingLevelMax:
+			if t.Subsampling == -1 {
+				t.Subsampling = int(e - subsamplingLevel0)
+			}
+		}
+	}
+	return t
+}
+
+// JPEG returns the JPEG quality and subsampling to use. JPEG is only enabled
+// when the client asked for a quality, otherwise quality is zero.
+func (t *Tuning) JPEG() (quality, subsampling int) {
+	switch {
+	case t.FineQuality >= 0:
+		quality, subsampling = t.FineQuality, Subsample4X
+		if t.QualityLevel >= 0 {
+			subsampling = tightQualityLevels[t.QualityLevel].subsampling
+		}
+		if quality == 0 {
+			quality = 1
+		}
+	case t.QualityLevel >= 0:
+		level := tightQualityLevels[t.QualityLevel]
+		quality, subsampling = level.quality, level.subsampling
+	default:
+		return 0, Subsample4X
+	}
+	if t.Subsampling >= 0 {
+		subsampling = t.Subsampling
+	}
+	return quality, subsampling
+}
+
+// ZlibLevel returns the zlib compression level matching the client's
+// compression level.
+func (t *Tuning) ZlibLevel() int {
+	if t.CompressLevel < 0 {
+		return zlib.DefaultCompression
+	}
+	return t.CompressLevel
+}
+
+// PNGLevel returns the PNG compression level matching the client's compression
+// level.
+func (t *Tuning) PNGLevel() png.CompressionLevel {
+	switch {
+	case t.CompressLevel < 0:
+		return png.DefaultCompression
+	case t.CompressLevel == 0:
+		return png.NoCompression
+	case t.CompressLevel <= 3:
+		return png.BestSpeed
+	case t.CompressLevel <= 6:
+		return png.DefaultCompression
+	}
+	return png.BestCompression
+}
+
+// subsampledImage converts img for a JPEG encoder using the given chroma
+// subsampling. libjpeg subsamples RGBA input 4:2:0 (4X) on its own, so it is
+// returned as is, as are 8X and 16X which the JPEG encoder has no support for.
+func subsampledImage(img *image.RGBA, subsampling int) image.Image {
+	b := img.Bounds()
+	var ratio image.YCbCrSubsampleRatio
+	switch subsampling {
+	case Subsample1X:
+		ratio = image.YCbCrSubsampleRatio444
+	case Subsample2X:
+		ratio = image.YCbCrSubsampleRatio422
+	case SubsampleGray:
+		gray := image.NewGray(b)
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				gray.Set(x, y, img.At(x, y))
+			}
+		}
+		return gray
+	default:
+		return img
+	}
+
+	// libjpeg reads raw planes in whole MCUs, so the planes are padded to a
+	// multiple of 16 pixels and the visible area is returned as a sub image.
+	pw, ph := (b.Dx()+15)&^15, (b.Dy()+15)&^15
+	out := image.NewYCbCr(image.Rect(0, 0, pw, ph), ratio)
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			o := img.PixOffset(b.Min.X+x, b.Min.Y+y)
+			yy, cb, cr := color.RGBToYCbCr(img.Pix[o], img.Pix[o+1], img.Pix[o+2])
+			out.Y[out.YOffset(x, y)] = yy
+			c := out.COffset(x, y)
+			out.Cb[c], out.Cr[c] = cb, cr
+		}
+	}
+	return out.SubImage(image.Rect(0, 0, b.Dx(), b.Dy()))
+}
@@ -1,53 +1,91 @@
 package encodings
 
 import (
-	"bytes"
 	"encoding/binary"
 	"image"
-	"strconv"
 
 	"github.com/suutaku/go-vnc/internal/types"
 )
 
 func applyPixelFormat(img *image.RGBA, format *types.PixelFormat) []byte {
-	formattedImage := new(bytes.Buffer)
 	b := img.Bounds()
-	width, height := b.Dx(), b.Dy()
-	for y := 0; y < height; y++ {
-		for x := 0; x < width; x++ {
-			col := img.At(x, y)
-			r16, g16, b16, _ := col.RGBA()
-			r16 = inRange(r16, format.RedMax)
-			g16 = inRange(g16, format.GreenMax)
-			b16 = inRange(b16, format.BlueMax)
-			var u32 uint32 = (r16 << format.RedShift) |
-				(g16 << format.GreenShift) |
-				(b16 << format.BlueShift)
-			var v interface{}
-			switch format.BPP {
-			case 32:
-				v = u32
-			case 16:
-				v = uint16(u32)
-			case 8:
-				v = uint8(u32)
-			}
-			if format.BigEndian != 0 {
-				binary.Write(formattedImage, binary.BigEndian, v)
-			} else {
-				binary.Write(formattedImage, binary.LittleEndian, v)
-			}
+	bpp := bytesPerPixel(format)
+	out := make([]byte, b.Dx()*b.Dy()*bpp)
+	i := 0
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			putPixel(out[i:], rgbaPixel(img, x, y, format), format)
+			i += bpp
 		}
 	}
-	return formattedImage.Bytes()
+	return out
 }
 
-func inRange(v uint32, max uint16) uint32 {
-	switch max {
-	case 0x1f: // 5 bits
-		return v >> (16 - 5)
-	case 0xff:
-		return v >> 8
+// rgbaPixel returns the pixel at x, y of the image packed into the given format.
+func rgbaPixel(img *image.RGBA, x, y int, format *types.PixelFormat) uint32 {
+	o := img.PixOffset(x, y)
+	return pixelValue(img.Pix[o], img.Pix[o+1], img.Pix[o+2], format)
+}
+
+// pixelValue packs an 8-bit per channel colour into a pixel value for the
+// given format, scaling each channel down to the maximum the client asked for.
+func pixelValue(r, g, b uint8, format *types.PixelFormat) uint32 {
+	return scaleChannel(r, format.RedMax)<<format.RedShift |
+		scaleChannel(g, format.GreenMax)<<format.GreenShift |
+		scaleChannel(b, format.BlueMax)<<format.BlueShift
+}
+
+func scaleChannel(c uint8, max uint16) uint32 {
+	return (uint32(c)*uint32(max) + 127) / 255
+}
+
+// bytesPerPixel returns the size of a single PIXEL on the wire.
+func bytesPerPixel(format *types.PixelFormat) int {
+	switch format.BPP {
+	case 32:
+		return 4
+	case 16:
+		return 2
+	}
+	return 1
+}
+
+// putPixel writes the pixel value v to dst using the width and byte order of
+// the given format. dst must be at least bytesPerPixel(format) long.
+func putPixel(dst []byte, v uint32, format *types.PixelFormat) {
+	var order binary.ByteOrder = binary.LittleEndian
+	if format.BigEndian != 0 {
+		order = binary.BigEndian
+	}
+	switch format.BPP {
+	case 32:
+		order.PutUint32(dst, v)
+	case 16:
+		order.PutUint16(dst, uint16(v))
+	default:
+		dst[0] = uint8(v)
+	}
+}
+
+// splitRect splits r into a grid of rectangles no wider than maxWidth and
+// covering no more than maxSize pixels each.
+func splitRect(r image.Rectangle, maxWidth, maxSize int) []image.Rectangle {
+	w, h := r.Dx(), r.Dy()
+	if w <= maxWidth && w*h <= maxSize {
+		return []image.Rectangle{r}
+	}
+	// Spread the width evenly over the fewest columns that fit maxWidth.
+	cols := (w + maxWidth - 1) / maxWidth
+	subW := (w + cols - 1) / cols
+	subH := maxSize / subW
+	if subH < 1 {
+		subH = 1
+	}
+	out := make([]image.Rectangle, 0)
+	for y := r.Min.Y; y < r.Max.Y; y += subH {
+		for x := r.Min.X; x < r.Max.X; x += subW {
+			out = append(out, image.Rect(x, y, x+subW, y+subH).Intersect(r))
+		}
 	}
-	panic("todo; max value = " + strconv.Itoa(int(max)))
+	return out
 }
@@ -0,0 +1,258 @@
+package encodings
+
+import (
+	"bytes"
+	"compress/zlib"
+	"image"
+	"io"
+	"log"
+
+	"github.com/suutaku/go-vnc/internal/types"
+	"github.com/suutaku/go-vnc/internal/utils"
+)
+
+// ZRLE tile dimensions and subencoding limits.
+const (
+	zrleTileSize    = 64
+	zrleMaxPalette  = 127
+	zrleMaxPacked   = 16
+	zrleRaw         = 0
+	zrleSolid       = 1
+	zrlePlainRLE    = 128
+	zrlePaletteRLE  = 128
+	zrleRunMarker   = 0x80
+	zrleRunLenLimit = 255
+)
+
+// ZRLEEncoding implements an Encoding intercace using ZRLE encoding.
+//
+// A single zlib stream is kept open for the lifetime of the connection, so every
+// connection needs its own instance. See NewInstance.
+type ZRLEEncoding struct {
+	compressed bytes.Buffer
+	zw         *zlib.Writer
+	tile       bytes.Buffer
+	pixels     []uint32
+	level      int
+}
+
+// Code returns the code
+func (z *ZRLEEncoding) Code() int32 { return 16 }
+
+// NewInstance returns a ZRLE encoder with its own zlib stream.
+func (z *ZRLEEncoding) NewInstance() Encoding { return &ZRLEEncoding{level: zlib.DefaultCompression} }
+
+// Tune applies the client's compression level. ZRLE has no way to tell the
+// client to restart its inflate stream, so the level only takes effect if it
+// is set before the first rectangle is sent.
+func (z *ZRLEEncoding) Tune(tuning *Tuning) {
+	if z.zw == nil {
+		z.level = tuning.ZlibLevel()
+	}
+}
+
+// HandleBuffer handles an image sample.
+func (z *ZRLEEncoding) HandleBuffer(w io.Writer, f *types.PixelFormat, img *image.RGBA) {
+	if z.zw == nil {
+		z.zw, _ = zlib.NewWriterLevel(&z.compressed, z.level)
+	}
+
+	cp := newCPixel(f)
+	b := img.Bounds()
+	z.tile.Reset()
+	for y := b.Min.Y; y < b.Max.Y; y += zrleTileSize {
+		for x := b.Min.X; x < b.Max.X; x += zrleTileSize {
+			tile := image.Rect(x, y, x+zrleTileSize, y+zrleTileSize).Intersect(b)
+			z.encodeTile(f, cp, img, tile)
+		}
+	}
+
+	if _, err := z.zw.Write(z.tile.Bytes()); err != nil {
+		log.Println("[zrle] Could not compress tile data:", err)
+		return
+	}
+	if err := z.zw.Flush(); err != nil {
+		log.Println("[zrle] Could not flush zlib stream:", err)
+		return
+	}
+
+	utils.Write(w, uint32(z.compressed.Len()))
+	utils.Write(w, z.compressed.Bytes())
+	z.compressed.Reset()
+}
+
+func (z *ZRLEEncoding) encodeTile(f *types.PixelFormat, cp cpixel, img *image.RGBA, r image.Rectangle) {
+	w, h := r.Dx(), r.Dy()
+
+	z.pixels = z.pixels[:0]
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			z.pixels = append(z.pixels, rgbaPixel(img, x, y, f))
+		}
+	}
+	px := z.pixels
+
+	// Gather the palette and the cost of each run-length variant in one pass.
+	palette := make([]uint32, 0, zrleMaxPalette)
+	index := make(map[uint32]uint8, zrleMaxPalette)
+	var runs, runBytes, paletteRunBytes int
+	for i := 0; i < len(px); {
+		run := 1
+		for i+run < len(px) && px[i+run] == px[i] {
+			run++
+		}
+		runs++
+		runBytes += runLengthSize(run)
+		if run == 1 {
+			paletteRunBytes++
+		} else {
+			paletteRunBytes += 1 + runLengthSize(run)
+		}
+		if len(palette) <= zrleMaxPalette {
+			if _, ok := index[px[i]]; !ok {
+				index[px[i]] = uint8(len(palette))
+				palette = append(palette, px[i])
+			}
+		}
+		i += run
+	}
+
+	if len(palette) == 1 {
+		z.tile.WriteByte(zrleSolid)
+		cp.write(&z.tile, palette[0])
+		return
+	}
+
+	sub := zrleRaw
+	best := w * h * cp.size
+	if size := runs*cp.size + runBytes; size < best {
+		sub, best = zrlePlainRLE, size
+	}
+	if len(palette) <= zrleMaxPalette {
+		if size := len(palette)*cp.size + paletteRunBytes; size < best {
+			sub, best = zrlePaletteRLE+len(palette), size
+		}
+		if len(palette) <= zrleMaxPacked {
+			if size := len(palette)*cp.size + h*((w*packedBits(len(palette))+7)/8); size < best {
+				sub = len(palette)
+			}
+		}
+	}
+
+	z.tile.WriteByte(uint8(sub))
+	switch {
+	case sub == zrleRaw:
+		for _, p := range px {
+			cp.write(&z.tile, p)
+		}
+	case sub == zrlePlainRLE:
+		forEachRun(px, func(p uint32, run int) {
+			cp.write(&z.tile, p)
+			writeRunLength(&z.tile, run)
+		})
+	case sub > zrlePaletteRLE:
+		writePalette(&z.tile, cp, palette)
+		forEachRun(px, func(p uint32, run int) {
+			if run == 1 {
+				z.tile.WriteByte(index[p])
+				return
+			}
+			z.tile.WriteByte(index[p] | zrleRunMarker)
+			writeRunLength(&z.tile, run)
+		})
+	default:
+		writePalette(&z.tile, cp, palette)
+		bits := packedBits(len(palette))
+		for y := 0; y < h; y++ {
+			var cur uint8
+			var used int
+			for x := 0; x < w; x++ {
+				cur = cur<<bits | index[px[y*w+x]]
+				used += bits
+				if used == 8 {
+					z.tile.WriteByte(cur)
+					cur, used = 0, 0
+				}
+			}
+			if used > 0 {
+				z.tile.WriteByte(cur << (8 - used))
+			}
+		}
+	}
+}
+
+func writePalette(buf *bytes.Buffer, cp cpixel, palette []uint32) {
+	for _, p := range palette {
+		cp.write(buf, p)
+	}
+}
+
+func forEachRun(px []uint32, fn func(p uint32, run int)) {
+	for i := 0; i < len(px); {
+		run := 1
+		for i+run < len(px) && px[i+run] == px[i] {
+			run++
+		}
+		fn(px[i], run)
+		i += run
+	}
+}
+
+// runLengthSize returns the number of bytes needed to encode a ZRLE run length.
+func runLengthSize(run int) int { return (run-1)/zrleRunLenLimit + 1 }
+
+func writeRunLength(buf *bytes.Buffer, run int) {
+	run--
+	for ; run >= zrleRunLenLimit; run -= zrleRunLenLimit {
+		buf.WriteByte(zrleRunLenLimit)
+	}
+	buf.WriteByte(uint8(run))
+}
+
+func packedBits(paletteSize int) int {
+	switch {
+	case paletteSize <= 2:
+		return 1
+	case paletteSize <= 4:
+		return 2
+	}
+	return 4
+}
+
+// cpixel describes how a pixel is compressed into a ZRLE CPIXEL for a given
+// pixel format.
+type cpixel struct {
+	format *types.PixelFormat
+	size   int
+	// offset of the CPIXEL bytes in the full little or big endian pixel.
+	offset int
+}
+
+// newCPixel works out the CPIXEL layout for the given format. A CPIXEL is only
+// three bytes wide for 32bpp true colour formats with a depth of 24 or less
+// whose colour bits all live in either the three least or most significant bytes.
+func newCPixel(f *types.PixelFormat) cpixel {
+	cp := cpixel{format: f, size: bytesPerPixel(f)}
+	if f.TrueColour == 0 || f.BPP != 32 || f.Depth > 24 {
+		return cp
+	}
+	mask := uint32(f.RedMax)<<f.RedShift | uint32(f.GreenMax)<<f.GreenShift | uint32(f.BlueMax)<<f.BlueShift
+	lsb := mask&0xff000000 == 0
+	msb := mask&0x000000ff == 0
+	if !lsb && !msb {
+		return cp
+	}
+	cp.size = 3
+	// Dropping the most significant byte means dropping the last byte of a
+	// little endian pixel, or the first byte of a big endian one.
+	if lsb == (f.BigEndian != 0) {
+		cp.offset = 1
+	}
+	return cp
+}
+
+func (cp cpixel) write(buf *bytes.Buffer, p uint32) {
+	var tmp [4]byte
+	putPixel(tmp[:], p, cp.format)
+	buf.Write(tmp[cp.offset : cp.offset+cp.size])
+}
@@ -0,0 +1,192 @@
+package encodings
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"testing"
+
+	"github.com/suutaku/go-vnc/internal/types"
+)
+
+// zrleDecoder decodes ZRLE rectangles from a single zlib stream, as clients do.
+type zrleDecoder struct {
+	testDecoder
+	format *types.PixelFormat
+	// Subencodings seen so far.
+	seen map[string]bool
+}
+
+// cpixel reads a CPIXEL, which drops the unused byte of 32bpp true colour
+// pixels whose colours fit in three bytes.
+func (d *zrleDecoder) cpixel() uint32 {
+	d.t.Helper()
+	f := d.format
+	if f.TrueColour == 0 || f.BPP != 32 || f.Depth > 24 {
+		return d.pixel(f)
+	}
+	mask := uint32(f.RedMax)<<f.RedShift | uint32(f.GreenMax)<<f.GreenShift | uint32(f.BlueMax)<<f.BlueShift
+	fitsLow, fitsHigh := mask <= 0xffffff, mask&0xff == 0
+	if !fitsLow && !fitsHigh {
+		return d.pixel(f)
+	}
+	b := d.bytes(3)
+	v := uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+	if f.BigEndian != 0 {
+		v = uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+	}
+	if fitsLow {
+		return v
+	}
+	return v << 8
+}
+
+func (d *zrleDecoder) runLength() int {
+	d.t.Helper()
+	n := 1
+	for {
+		b := d.byte()
+		n += b
+		if b != 255 {
+			return n
+		}
+	}
+}
+
+func (d *zrleDecoder) palette(n int) []uint32 {
+	d.t.Helper()
+	out := make([]uint32, n)
+	for i := range out {
+		out[i] = d.cpixel()
+	}
+	return out
+}
+
+func (d *zrleDecoder) rect(w, h int) []uint32 {
+	d.t.Helper()
+	out := make([]uint32, w*h)
+	for ty := 0; ty < h; ty += 64 {
+		for tx := 0; tx < w; tx += 64 {
+			tile := image.Rect(tx, ty, tx+64, ty+64).Intersect(image.Rect(0, 0, w, h))
+			px := d.tile(tile.Dx(), tile.Dy())
+			for y := 0; y < tile.Dy(); y++ {
+				copy(out[(tile.Min.Y+y)*w+tile.Min.X:], px[y*tile.Dx():(y+1)*tile.Dx()])
+			}
+		}
+	}
+	return out
+}
+
+func (d *zrleDecoder) tile(w, h int) []uint32 {
+	d.t.Helper()
+	px := make([]uint32, 0, w*h)
+	sub := d.byte()
+	switch {
+	case sub == 0:
+		d.seen["raw"] = true
+		for i := 0; i < w*h; i++ {
+			px = append(px, d.cpixel())
+		}
+	case sub == 1:
+		d.seen["solid"] = true
+		p := d.cpixel()
+		for i := 0; i < w*h; i++ {
+			px = append(px, p)
+		}
+	case sub <= 16:
+		d.seen["packed palette"] = true
+		pal := d.palette(sub)
+		bits := 4
+		if sub <= 2 {
+			bits = 1
+		} else if sub <= 4 {
+			bits = 2
+		}
+		for y := 0; y < h; y++ {
+			var cur, left int
+			for x := 0; x < w; x++ {
+				if left == 0 {
+					cur, left = d.byte(), 8
+				}
+				left -= bits
+				i := cur >> left & (1<<bits - 1)
+				if i >= len(pal) {
+					d.t.Fatalf("packed index %d outside palette of %d", i, len(pal))
+				}
+				px = append(px, pal[i])
+			}
+		}
+	case sub < 128:
+		d.t.Fatalf("invalid ZRLE subencoding %d", sub)
+	case sub == 128:
+		d.seen["plain RLE"] = true
+		for len(px) < w*h {
+			p, n := d.cpixel(), d.runLength()
+			for i := 0; i < n; i++ {
+				px = append(px, p)
+			}
+		}
+	default:
+		d.seen["palette RLE"] = true
+		pal := d.palette(sub - 128)
+		for len(px) < w*h {
+			i, n := d.byte(), 1
+			if i&128 != 0 {
+				n = d.runLength()
+			}
+			if i&127 >= len(pal) {
+				d.t.Fatalf("RLE index %d outside palette of %d", i&127, len(pal))
+			}
+			for k := 0; k < n; k++ {
+				px = append(px, pal[i&127])
+			}
+		}
+	}
+	if len(px) != w*h {
+		d.t.Fatalf("tile of %dx%d decoded to %d pixels", w, h, len(px))
+	}
+	return px
+}
+
+func TestZRLERoundTrip(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, tf := range testFormats {
+		t.Run(tf.name, func(t *testing.T) {
+			enc := (&ZRLEEncoding{}).NewInstance()
+			// Every rectangle continues the zlib stream of the ones before.
+			var stream bytes.Buffer
+			var images []*image.RGBA
+			for i, size := range testSizes {
+				img := testImage(size, 64, int64(i))
+				images = append(images, img)
+				var rect bytes.Buffer
+				enc.HandleBuffer(&rect, tf.format, img)
+				b := rect.Bytes()
+				if n := binary.BigEndian.Uint32(b); int(n) != len(b)-4 {
+					t.Fatalf("%v: length %d for %d bytes of data", size, n, len(b)-4)
+				}
+				stream.Write(b[4:])
+			}
+
+			zr, err := zlib.NewReader(&stream)
+			if err != nil {
+				t.Fatal(err)
+			}
+			d := &zrleDecoder{testDecoder: testDecoder{t: t, r: zr}, format: tf.format, seen: seen}
+			for _, img := range images {
+				size := img.Bounds().Size()
+				t.Run(fmt.Sprint(size), func(t *testing.T) {
+					d.t = t
+					checkPixels(t, d.rect(size.X, size.Y), img, tf.format)
+				})
+			}
+		})
+	}
+	for _, sub := range []string{"raw", "solid", "packed palette", "plain RLE", "palette RLE"} {
+		if !seen[sub] {
+			t.Errorf("no tile used %s", sub)
+		}
+	}
+}
@@ -1,7 +1,13 @@
 package events
 
 import (
+	"fmt"
+	"io"
+
+	"github.com/sirupsen/logrus"
+	"github.com/suutaku/go-vnc/internal/auth"
 	"github.com/suutaku/go-vnc/internal/buffer"
+	"github.com/suutaku/go-vnc/internal/clipboard"
 	"github.com/suutaku/go-vnc/internal/display"
 	"github.com/suutaku/go-vnc/internal/types"
 )
@@ -18,16 +24,75 @@ func (c *ClientCutText) Handle(buf *buffer.ReadWriter, d *display.Display) error
 
 	buf.ReadPadding(3)
 
-	if err := buf.Read(&req.Length); err != nil {
+	// A negative length marks an Extended Clipboard message
+	var length int32
+	if err := buf.Read(&length); err != nil {
 		return err
 	}
+	if length < 0 {
+		return c.handleExtended(buf, d, uint32(-int64(length)))
+	}
 
+	limits := d.GetClipboardLimits()
+	if uint32(length) > limits[clipboard.FormatText] {
+		// Skip the text so the connection can carry on.
+		logrus.Warnf("Dropping cut text of %d bytes, over the limit of %d", length, limits[clipboard.FormatText])
+		_, err := io.CopyN(io.Discard, buf.Reader(), int64(length))
+		return err
+	}
+	req.Length = uint32(length)
 	req.Text = make([]byte, req.Length)
 
 	if err := buf.Read(&req.Text); err != nil {
 		return err
 	}
 
+	if !d.Allows(auth.PermClipboardIn) {
+		return nil
+	}
 	d.DispatchClientCutText(&req)
 	return nil
 }
+
+func (c *ClientCutText) handleExtended(buf *buffer.ReadWriter, d *display.Display, length uint32) error {
+	limits := d.GetClipboardLimits()
+	if length < 4 || length > limits.MaxMessageSize() {
+		return fmt.Errorf("invalid extended clipboard message length %d", length)
+	}
+
+	var flags uint32
+	if err := buf.Read(&flags); err != nil {
+		return err
+	}
+	payload := make([]byte, length-4)
+	if err := buf.Read(&payload); err != nil {
+		return err
+	}
+
+	msg, err := clipboard.Parse(flags, payload, limits)
+	switch {
+	case err == clipboard.ErrTooLarge:
+		logrus.Warn("Dropping clipboard data from client: ", err)
+	case err != nil:
+		// The whole message was read, so the connection can carry on.
+		logrus.Error("Invalid clipboard message from client: ", err)
+		return nil
+	}
+	if !d.Allows(clipboardPermission(msg)) {
+		return nil
+	}
+	d.DispatchClipboardMessage(msg)
+	return nil
+}
+
+// clipboardPermission returns the permission the client needs for an Extended
+// Clipboard message: sending clipboard data in, or asking for the host's.
+func clipboardPermission(m *clipboard.Message) auth.Permissions {
+	switch m.Action() {
+	case clipboard.ActionProvide, clipboard.ActionNotify:
+		return auth.PermClipboardIn
+	case clipboard.ActionRequest, clipboard.ActionPeek:
+		return auth.PermClipboardOut
+	}
+	return 0
+}
@@ -0,0 +1,24 @@
+package events
+
+import (
+	"github.com/suutaku/go-vnc/internal/buffer"
+	"github.com/suutaku/go-vnc/internal/display"
+	"github.com/suutaku/go-vnc/internal/types"
+)
+
+// EnableContinuousUpdates handles the client turning continuous updates on or off.
+type EnableContinuousUpdates struct{}
+
+// Code returns the code.
+func (e *EnableContinuousUpdates) Code() uint8 { return 150 }
+
+// Handle handles the event.
+func (e *EnableContinuousUpdates) Handle(buf *buffer.ReadWriter, d *display.Display) error {
+	var req types.EnableContinuousUpdates
+	if err := buf.ReadInto(&req); err != nil {
+		return err
+	}
+
+	d.DispatchContinuousUpdates(&req)
+	return nil
+}
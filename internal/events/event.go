@@ -19,6 +19,10 @@ var DefaultEvents = []Event{
 	&KeyEvent{},
 	&PointerEvent{},
 	&ClientCutText{},
+	&EnableContinuousUpdates{},
+	&Fence{},
+	&SetDesktopSize{},
+	&QEMUClientMessage{},
 }
 
 // GetDefaults returns a slice of the default event handlers.
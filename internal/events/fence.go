@@ -0,0 +1,47 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/suutaku/go-vnc/internal/buffer"
+	"github.com/suutaku/go-vnc/internal/display"
+	"github.com/suutaku/go-vnc/internal/types"
+)
+
+// Fence handles fence messages, either requests from the client or responses to
+// the fences sent by the server.
+type Fence struct{}
+
+// Code returns the code.
+func (f *Fence) Code() uint8 { return 248 }
+
+// Handle handles the event.
+func (f *Fence) Handle(buf *buffer.ReadWriter, d *display.Display) error {
+	var req types.Fence
+
+	if err := buf.ReadPadding(3); err != nil {
+		return err
+	}
+	if err := buf.Read(&req.Flags); err != nil {
+		return err
+	}
+
+	var length uint8
+	if err := buf.Read(&length); err != nil {
+		return err
+	}
+	if length > types.FenceMaxPayload {
+		return fmt.Errorf("fence payload of %d bytes exceeds %d", length, types.FenceMaxPayload)
+	}
+	req.Payload = make([]byte, length)
+	if err := buf.Read(&req.Payload); err != nil {
+		return err
+	}
+
+	done := d.DispatchFence(&req)
+	// Messages after a BlockAfter fence may only be handled once it is answered.
+	if req.IsRequest() && req.Flags&types.FenceBlockAfter != 0 {
+		<-done
+	}
+	return nil
+}
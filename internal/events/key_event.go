@@ -1,6 +1,7 @@
 package events
 
 import (
+	"github.com/suutaku/go-vnc/internal/auth"
 	"github.com/suutaku/go-vnc/internal/buffer"
 	"github.com/suutaku/go-vnc/internal/display"
 	"github.com/suutaku/go-vnc/internal/types"
@@ -23,6 +24,9 @@ func (s *KeyEvent) Handle(buf *buffer.ReadWriter, d *display.Display) error {
 	if err := buf.Read(&req.Key); err != nil {
 		return err
 	}
+	if !d.Allows(auth.PermKeyboard) {
+		return nil
+	}
 	d.DispatchKeyEvent(&req)
 	return nil
 }
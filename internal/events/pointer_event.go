@@ -1,6 +1,7 @@
 package events
 
 import (
+	"github.com/suutaku/go-vnc/internal/auth"
 	"github.com/suutaku/go-vnc/internal/buffer"
 	"github.com/suutaku/go-vnc/internal/display"
 	"github.com/suutaku/go-vnc/internal/types"
@@ -18,6 +19,9 @@ func (s *PointerEvent) Handle(buf *buffer.ReadWriter, d *display.Display) error
 	if err := buf.ReadInto(&req); err != nil {
 		return err
 	}
+	if !d.Allows(auth.PermPointer) {
+		return nil
+	}
 	d.DispatchPointerEvent(&req)
 	return nil
 }
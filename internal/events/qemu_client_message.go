@@ -0,0 +1,52 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/suutaku/go-vnc/internal/auth"
+	"github.com/suutaku/go-vnc/internal/buffer"
+	"github.com/suutaku/go-vnc/internal/display"
+	"github.com/suutaku/go-vnc/internal/types"
+)
+
+// QEMU client message subtypes.
+const qemuExtendedKeyEvent = 0
+
+// QEMUClientMessage handles QEMU client messages. Only extended key events,
+// which carry the scancode of the key along with the keysym, are supported.
+type QEMUClientMessage struct{}
+
+// Code returns the code.
+func (q *QEMUClientMessage) Code() uint8 { return 255 }
+
+// Handle handles the event.
+func (q *QEMUClientMessage) Handle(buf *buffer.ReadWriter, d *display.Display) error {
+	var subtype uint8
+	if err := buf.Read(&subtype); err != nil {
+		return err
+	}
+	if subtype != qemuExtendedKeyEvent {
+		// The length of other subtypes is unknown, so the stream cannot be resumed.
+		return fmt.Errorf("unsupported QEMU client message subtype %d", subtype)
+	}
+
+	var down uint16
+	var req types.KeyEvent
+	if err := buf.Read(&down); err != nil {
+		return err
+	}
+	if err := buf.Read(&req.Key); err != nil {
+		return err
+	}
+	if err := buf.Read(&req.Scancode); err != nil {
+		return err
+	}
+	if down != 0 {
+		req.DownFlag = 1
+	}
+	if !d.Allows(auth.PermKeyboard) {
+		return nil
+	}
+	d.DispatchKeyEvent(&req)
+	return nil
+}
@@ -0,0 +1,51 @@
+package events
+
+import (
+	"github.com/suutaku/go-vnc/internal/auth"
+	"github.com/suutaku/go-vnc/internal/buffer"
+	"github.com/suutaku/go-vnc/internal/display"
+	"github.com/suutaku/go-vnc/internal/types"
+)
+
+// SetDesktopSize handles the client asking for a new framebuffer size.
+type SetDesktopSize struct{}
+
+// Code returns the code.
+func (s *SetDesktopSize) Code() uint8 { return 251 }
+
+// Handle handles the event.
+func (s *SetDesktopSize) Handle(buf *buffer.ReadWriter, d *display.Display) error {
+	var req types.SetDesktopSize
+
+	if err := buf.ReadPadding(1); err != nil {
+		return err
+	}
+	if err := buf.Read(&req.Width); err != nil {
+		return err
+	}
+	if err := buf.Read(&req.Height); err != nil {
+		return err
+	}
+
+	var numScreens uint8
+	if err := buf.Read(&numScreens); err != nil {
+		return err
+	}
+	if err := buf.ReadPadding(1); err != nil {
+		return err
+	}
+
+	req.Screens = make([]types.Screen, int(numScreens))
+	for i := range req.Screens {
+		if err := buf.ReadInto(&req.Screens[i]); err != nil {
+			return err
+		}
+	}
+
+	if !d.Allows(auth.PermResize) {
+		d.RefuseSetDesktopSize(&req)
+		return nil
+	}
+	d.DispatchSetDesktopSize(&req)
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/suutaku/go-vnc/internal/buffer"
 	"github.com/suutaku/go-vnc/internal/display"
+	"github.com/suutaku/go-vnc/internal/encodings"
 )
 
 // SetEncodings handles the client set-encodings event.
@@ -37,18 +38,19 @@ func (s *SetEncodings) Handle(buf *buffer.ReadWriter, d *display.Display) error
 	return nil
 }
 
-func splitPseudoEncodings(encs []int32) (encodings, pseudoEncodings []int32) {
-	encodings = make([]int32, 0)
-	var i int
-	for i = 0; i < len(encs); i++ {
-		encodings = append(encodings, encs[i])
-		if encs[i] == 0 {
-			break
+// splitPseudoEncodings separates the real encodings from the pseudo-encodings,
+// keeping the client's order of preference. Clients are free to mix the two, so
+// anything negative is a pseudo-encoding, except for TightPNG.
+func splitPseudoEncodings(all []int32) (encs, pseudo []int32) {
+	encs = make([]int32, 0)
+	pseudo = make([]int32, 0)
+	tightPNG := (&encodings.TightPNGEncoding{}).Code()
+	for _, e := range all {
+		if e >= 0 || e == tightPNG {
+			encs = append(encs, e)
+		} else {
+			pseudo = append(pseudo, e)
 		}
 	}
-	if i == len(encs)-1 {
-		return
-	}
-	pseudoEncodings = encs[i+1:]
 	return
 }
@@ -0,0 +1,90 @@
+// Package input delivers the keyboard, pointer and clipboard input of RFB
+// clients to wherever it should go: the desktop the server runs on, an
+// application, a virtual machine or a test.
+package input
+
+import (
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/suutaku/go-vnc/internal/clipboard"
+)
+
+// Sink is implemented by the destinations of client input. A sink is shared by
+// all the clients of a server, so its methods may be called concurrently.
+type Sink interface {
+	// KeyDown presses a key. Keysym is the X11 keysym of the key and scancode
+	// its XT scancode, or zero if the client did not send one. Further calls
+	// for a key that is down are auto-repeat.
+	KeyDown(keysym, scancode uint32) error
+	// KeyUp releases a key, with the same keysym and scancode it was pressed
+	// with.
+	KeyUp(keysym, scancode uint32) error
+	// PointerMove moves the pointer to the given framebuffer position.
+	PointerMove(x, y int) error
+	// ButtonDown presses a pointer button.
+	ButtonDown(b Button) error
+	// ButtonUp releases a pointer button.
+	ButtonUp(b Button) error
+	// Scroll scrolls by the given number of steps. Positive dx scrolls right
+	// and positive dy scrolls down.
+	Scroll(dx, dy int) error
+	// Clipboard returns the clipboard clients share through the sink.
+	Clipboard() clipboard.Clipboard
+}
+
+// Button is a pointer button.
+type Button uint8
+
+// Pointer buttons, in the order of their bits in the RFB button mask.
+const (
+	ButtonLeft Button = iota
+	ButtonMiddle
+	ButtonRight
+)
+
+// Type is an enum used for selecting a sink.
+type Type string
+
+// Sink options.
+const (
+	SinkRobotgo  = "robotgo"
+	SinkRecorder = "recorder"
+)
+
+// Factory returns a new sink.
+type Factory func() Sink
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[Type]Factory)
+)
+
+// Register makes a sink type available to GetSink. Registering a type again
+// replaces it, the built-in types cannot be replaced.
+func Register(t Type, f Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[t] = f
+}
+
+// GetSink returns a new sink of the given type, or nil if there is no such
+// type.
+func GetSink(t Type) Sink {
+	switch t {
+	case SinkRobotgo:
+		logrus.Info("robotgo input sink")
+		return &Robotgo{}
+	case SinkRecorder:
+		logrus.Info("recording input sink")
+		return NewRecorder()
+	}
+	registryMu.Lock()
+	f, ok := registry[t]
+	registryMu.Unlock()
+	if !ok {
+		return nil
+	}
+	logrus.Info(t, " input sink")
+	return f()
+}
@@ -0,0 +1,96 @@
+package input
+
+import (
+	"sync"
+
+	"github.com/suutaku/go-vnc/internal/clipboard"
+)
+
+// EventType is the kind of a recorded input event.
+type EventType int
+
+// Recorded event types.
+const (
+	EventKeyDown EventType = iota
+	EventKeyUp
+	EventPointerMove
+	EventButtonDown
+	EventButtonUp
+	EventScroll
+)
+
+// Event is an input event recorded by a Recorder.
+type Event struct {
+	Type EventType
+	// Keysym and Scancode are set for key events.
+	Keysym, Scancode uint32
+	// X and Y are the position for pointer moves and the steps for scrolls.
+	X, Y int
+	// Button is set for button events.
+	Button Button
+}
+
+// Recorder is a sink that records the input it receives in memory, for tests
+// and servers that only need to be looked at.
+type Recorder struct {
+	mu        sync.Mutex
+	events    []Event
+	clipboard clipboard.Memory
+}
+
+// NewRecorder returns a new recorder with no events.
+func NewRecorder() *Recorder { return &Recorder{} }
+
+// Events returns the events recorded so far, oldest first.
+func (r *Recorder) Events() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event(nil), r.events...)
+}
+
+// Reset forgets the recorded events.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
+
+// KeyDown records a key press.
+func (r *Recorder) KeyDown(keysym, scancode uint32) error {
+	return r.record(Event{Type: EventKeyDown, Keysym: keysym, Scancode: scancode})
+}
+
+// KeyUp records a key release.
+func (r *Recorder) KeyUp(keysym, scancode uint32) error {
+	return r.record(Event{Type: EventKeyUp, Keysym: keysym, Scancode: scancode})
+}
+
+// PointerMove records a pointer move.
+func (r *Recorder) PointerMove(x, y int) error {
+	return r.record(Event{Type: EventPointerMove, X: x, Y: y})
+}
+
+// ButtonDown records a button press.
+func (r *Recorder) ButtonDown(b Button) error {
+	return r.record(Event{Type: EventButtonDown, Button: b})
+}
+
+// ButtonUp records a button release.
+func (r *Recorder) ButtonUp(b Button) error {
+	return r.record(Event{Type: EventButtonUp, Button: b})
+}
+
+// Scroll records a scroll.
+func (r *Recorder) Scroll(dx, dy int) error {
+	return r.record(Event{Type: EventScroll, X: dx, Y: dy})
+}
+
+// Clipboard returns an in-memory clipboard.
+func (r *Recorder) Clipboard() clipboard.Clipboard { return &r.clipboard }
+
+func (r *Recorder) record(ev Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+	return nil
+}
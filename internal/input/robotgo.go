@@ -0,0 +1,132 @@
+package input
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/go-vgo/robotgo"
+	"github.com/suutaku/go-vnc/internal/clipboard"
+	"github.com/suutaku/go-vnc/internal/keysym"
+)
+
+// Robotgo sends input to the desktop the server runs on.
+type Robotgo struct {
+	mu sync.Mutex
+	// Combining character of a dead key, typed after the next character.
+	deadRune  rune
+	clipboard SystemClipboard
+}
+
+// KeyDown presses a key. Keys the desktop has no key for, such as accented
+// letters, are typed as characters instead.
+func (r *Robotgo) KeyDown(ks, scancode uint32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	name, ok := keyName(ks, scancode)
+	if !ok {
+		return r.typeKeysym(ks)
+	}
+	if err := robotgo.KeyToggle(name, "down"); err != nil {
+		return fmt.Errorf("press key %s: %v", name, err)
+	}
+	if _, ok := keysym.Rune(ks); ok {
+		r.typeDeadRune()
+	}
+	return nil
+}
+
+// KeyUp releases a key. Keys typed as characters have nothing to release.
+func (r *Robotgo) KeyUp(ks, scancode uint32) error {
+	name, ok := keyName(ks, scancode)
+	if !ok {
+		return nil
+	}
+	if err := robotgo.KeyToggle(name, "up"); err != nil {
+		return fmt.Errorf("release key %s: %v", name, err)
+	}
+	return nil
+}
+
+// PointerMove moves the pointer.
+func (r *Robotgo) PointerMove(x, y int) error {
+	robotgo.Move(x, y)
+	return nil
+}
+
+// ButtonDown presses a pointer button.
+func (r *Robotgo) ButtonDown(b Button) error { return robotgo.MouseDown(buttonNames[b]) }
+
+// ButtonUp releases a pointer button.
+func (r *Robotgo) ButtonUp(b Button) error { return robotgo.MouseUp(buttonNames[b]) }
+
+// Scroll scrolls the wheel. Robotgo scrolls up and left for positive values.
+func (r *Robotgo) Scroll(dx, dy int) error {
+	robotgo.Scroll(-dx, -dy)
+	return nil
+}
+
+// Clipboard returns the desktop clipboard.
+func (r *Robotgo) Clipboard() clipboard.Clipboard { return &r.clipboard }
+
+// SystemClipboard is the clipboard of the desktop the server runs on. Only
+// plain text is exchanged with it.
+type SystemClipboard struct{}
+
+// Read returns the current clipboard text.
+func (s *SystemClipboard) Read() (clipboard.Contents, error) {
+	text, err := robotgo.ReadAll()
+	if err != nil || text == "" {
+		return clipboard.Contents{}, err
+	}
+	return clipboard.Contents{clipboard.FormatText: []byte(text)}, nil
+}
+
+// Write replaces the clipboard text. Other formats are ignored.
+func (s *SystemClipboard) Write(c clipboard.Contents) error {
+	text, ok := c[clipboard.FormatText]
+	if !ok {
+		return nil
+	}
+	return robotgo.WriteAll(string(text))
+}
+
+// typeKeysym types the character of a keysym. Dead keys are held back until the
+// next character, which their combining character then follows.
+func (r *Robotgo) typeKeysym(ks uint32) error {
+	if dead, ok := keysym.DeadRune(ks); ok {
+		r.deadRune = dead
+		return nil
+	}
+	c, ok := keysym.Rune(ks)
+	if !ok {
+		return fmt.Errorf("unhandled keysym %#x", ks)
+	}
+	robotgo.UnicodeType(uint32(c))
+	r.typeDeadRune()
+	return nil
+}
+
+// typeDeadRune types the combining character of a pending dead key.
+func (r *Robotgo) typeDeadRune() {
+	if r.deadRune != 0 {
+		robotgo.UnicodeType(uint32(r.deadRune))
+		r.deadRune = 0
+	}
+}
+
+// keyName returns the robotgo name of the key. The scancode is preferred when
+// the client sent one, as it does not depend on the client's keyboard layout.
+func keyName(ks, scancode uint32) (string, bool) {
+	if scancode != 0 {
+		if name, ok := xtScancodeMap[scancode]; ok {
+			return name, true
+		}
+	}
+	return keysym.KeyName(ks)
+}
+
+var buttonNames = map[Button]string{
+	ButtonLeft:   "left",
+	ButtonMiddle: "center",
+	ButtonRight:  "right",
+}
@@ -0,0 +1,73 @@
+package input
+
+// xtScancodeMap maps XT scancodes, as sent in QEMU extended key events, to
+// robotgo key names. Scancodes name physical keys, so they are given the names
+// of the keys at those positions on a US keyboard. Keys sent with an 0xE0
+// prefix have the high bit set.
+var xtScancodeMap = map[uint32]string{
+	0x01: "esc",
+	0x02: "1", 0x03: "2", 0x04: "3", 0x05: "4", 0x06: "5",
+	0x07: "6", 0x08: "7", 0x09: "8", 0x0a: "9", 0x0b: "0",
+	0x0c: "-", 0x0d: "=",
+	0x0e: "backspace",
+	0x0f: "tab",
+	0x10: "q", 0x11: "w", 0x12: "e", 0x13: "r", 0x14: "t",
+	0x15: "y", 0x16: "u", 0x17: "i", 0x18: "o", 0x19: "p",
+	0x1a: "[", 0x1b: "]",
+	0x1c: "enter",
+	0x1d: "lctrl",
+	0x1e: "a", 0x1f: "s", 0x20: "d", 0x21: "f", 0x22: "g",
+	0x23: "h", 0x24: "j", 0x25: "k", 0x26: "l",
+	0x27: ";", 0x28: "'", 0x29: "`",
+	0x2a: "lshift",
+	0x2b: "\\",
+	0x2c: "z", 0x2d: "x", 0x2e: "c", 0x2f: "v", 0x30: "b",
+	0x31: "n", 0x32: "m",
+	0x33: ",", 0x34: ".", 0x35: "/",
+	0x36: "rshift",
+	0x37: "num*",
+	0x38: "lalt",
+	0x39: "space",
+	0x3a: "capslock",
+
+	0x3b: "f1", 0x3c: "f2", 0x3d: "f3", 0x3e: "f4", 0x3f: "f5",
+	0x40: "f6", 0x41: "f7", 0x42: "f8", 0x43: "f9", 0x44: "f10",
+	0x57: "f11", 0x58: "f12",
+	0x64: "f13", 0x65: "f14", 0x66: "f15", 0x67: "f16", 0x68: "f17",
+	0x69: "f18", 0x6a: "f19", 0x6b: "f20", 0x6c: "f21", 0x6d: "f22",
+	0x6e: "f23", 0x76: "f24",
+
+	0x45: "num_lock",
+	0x47: "num7", 0x48: "num8", 0x49: "num9", 0x4a: "num-",
+	0x4b: "num4", 0x4c: "num5", 0x4d: "num6", 0x4e: "num+",
+	0x4f: "num1", 0x50: "num2", 0x51: "num3",
+	0x52: "num0", 0x53: "num.",
+	0x59: "num_equal",
+
+	// 0xE0 prefixed keys
+	0x90: "audio_prev",
+	0x99: "audio_next",
+	0x9c: "num_enter",
+	0x9d: "rctrl",
+	0xa0: "audio_mute",
+	0xa2: "audio_play",
+	0xa4: "audio_stop",
+	0xae: "audio_vol_down",
+	0xb0: "audio_vol_up",
+	0xb5: "num/",
+	0xb7: "printscreen",
+	0xb8: "ralt",
+	0xc7: "home",
+	0xc8: "up",
+	0xc9: "pageup",
+	0xcb: "left",
+	0xcd: "right",
+	0xcf: "end",
+	0xd0: "down",
+	0xd1: "pagedown",
+	0xd2: "insert",
+	0xd3: "delete",
+	0xdb: "lcmd",
+	0xdc: "rcmd",
+	0xdd: "menu",
+}
@@ -0,0 +1,129 @@
+//go:build ignore
+// +build ignore
+
+// gen reads the X11 keysym headers and writes the keysym table used by the
+// keysym package.
+//
+//	go run gen.go -o keysyms_gen.go /usr/include/X11/keysymdef.h /usr/include/X11/XF86keysym.h
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	defineRe  = regexp.MustCompile(`^#define\s+(XF86XK_|XK_)([A-Za-z0-9_]+)\s+0x([0-9A-Fa-f]+)\s*(?:/\*(.*)\*/)?`)
+	unicodeRe = regexp.MustCompile(`U\+([0-9A-Fa-f]{4,6})`)
+)
+
+type keysym struct {
+	name       string
+	rune       rune
+	deprecated bool
+}
+
+func main() {
+	out := flag.String("o", "keysyms_gen.go", "output file")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatal("usage: gen [-o file] keysymdef.h [XF86keysym.h ...]")
+	}
+
+	syms := make(map[uint32]keysym)
+	var sources []string
+	for _, path := range flag.Args() {
+		if err := parse(path, syms); err != nil {
+			log.Fatal(err)
+		}
+		sources = append(sources, filepath.Base(path))
+	}
+	if err := check(syms); err != nil {
+		log.Fatal(err)
+	}
+
+	values := make([]uint32, 0, len(syms))
+	for v := range syms {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen.go from %s; DO NOT EDIT.\n\n", strings.Join(sources, ", "))
+	fmt.Fprintf(&buf, "package keysym\n\n")
+	fmt.Fprintf(&buf, "var keysyms = map[uint32]Keysym{\n")
+	for _, v := range values {
+		fmt.Fprintf(&buf, "\t%#08x: {%q, %#04x},\n", v, syms[v].name, syms[v].rune)
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parse adds the keysyms defined in the header at path. Where several names
+// share a value the first one is kept, unless it is marked deprecated.
+func parse(path string, syms map[uint32]keysym) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		m := defineRe.FindStringSubmatch(sc.Text())
+		if m == nil {
+			continue
+		}
+		v, err := strconv.ParseUint(m[3], 16, 32)
+		if err != nil {
+			return fmt.Errorf("%s: %s: %v", path, m[2], err)
+		}
+		name := m[2]
+		if m[1] == "XF86XK_" {
+			name = "XF86" + name
+		}
+		ks := keysym{name: name, deprecated: strings.Contains(m[4], "deprecated")}
+		if u := unicodeRe.FindStringSubmatch(m[4]); u != nil {
+			r, _ := strconv.ParseUint(u[1], 16, 32)
+			ks.rune = rune(r)
+		}
+		if prev, ok := syms[uint32(v)]; ok && !(prev.deprecated && !ks.deprecated) {
+			continue
+		}
+		syms[uint32(v)] = ks
+	}
+	return sc.Err()
+}
+
+// check makes sure every printable ASCII character has a keysym of the same
+// value that produces it, as the keysym package relies on that.
+func check(syms map[uint32]keysym) error {
+	for c := rune(0x20); c <= 0x7e; c++ {
+		ks, ok := syms[uint32(c)]
+		if !ok {
+			return fmt.Errorf("no keysym for %q", c)
+		}
+		if ks.rune != c {
+			return fmt.Errorf("keysym %s produces %q, want %q", ks.name, ks.rune, c)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+// Package keysym describes X11 keysyms, the key identifiers carried by RFB key
+// events: the character each one produces and the name of the key the input
+// backend presses for it.
+//
+// The keysym table is generated from the X11 keysymdef.h and XF86keysym.h
+// headers.
+package keysym
+
+import "fmt"
+
+//go:generate go run gen.go -o keysyms_gen.go /usr/include/X11/keysymdef.h /usr/include/X11/XF86keysym.h
+
+// unicodeOffset is added to a code point to form its Unicode keysym.
+const unicodeOffset = 0x01000000
+
+// Keysym describes a single keysym.
+type Keysym struct {
+	// Name is the X11 name of the keysym, without the XK_ prefix.
+	Name string
+	// Rune is the character the keysym produces, or zero if it produces none.
+	Rune rune
+}
+
+// Lookup returns the description of the keysym. Unicode keysyms missing from
+// the X11 headers are described by their code point.
+func Lookup(ks uint32) (Keysym, bool) {
+	if k, ok := keysyms[ks]; ok {
+		return k, true
+	}
+	if ks > unicodeOffset && ks <= unicodeOffset+0x10ffff {
+		return Keysym{Name: fmt.Sprintf("U%04X", ks-unicodeOffset), Rune: rune(ks - unicodeOffset)}, true
+	}
+	return Keysym{}, false
+}
+
+// Rune returns the character the keysym produces.
+func Rune(ks uint32) (rune, bool) {
+	k, ok := Lookup(ks)
+	if !ok || k.Rune == 0 {
+		return 0, false
+	}
+	return k.Rune, true
+}
+
+// KeyName returns the name the input backend uses for the key. Printable ASCII
+// characters are named by themselves, other keys come from keyNames. Keysyms
+// that only produce a character, such as accented letters, have no key name
+// and should be typed with Rune instead.
+func KeyName(ks uint32) (string, bool) {
+	if name, ok := keyNames[ks]; ok {
+		return name, true
+	}
+	if ks > 0x20 && ks < 0x7f {
+		return string(rune(ks)), true
+	}
+	return "", false
+}
+
+// DeadRune returns the combining character of a dead key. Dead keys produce
+// nothing themselves but modify the character typed next.
+func DeadRune(ks uint32) (rune, bool) {
+	r, ok := deadKeys[ks]
+	return r, ok
+}
+
+// BaseKey returns the keysym of the key producing ks without Shift on a US
+// layout: letters in lower case and shifted punctuation such as ! as the key
+// it is on, 1. Other keysyms are returned unchanged.
+func BaseKey(ks uint32) uint32 {
+	if base, ok := shiftedKeys[ks]; ok {
+		return base
+	}
+	if ks >= 'A' && ks <= 'Z' {
+		return ks + 'a' - 'A'
+	}
+	return ks
+}
+
+// shiftedKeys maps the punctuation typed with Shift on a US layout to the
+// keysym of its key.
+var shiftedKeys = map[uint32]uint32{
+	'~': '`',
+	'!': '1',
+	'@': '2',
+	'#': '3',
+	'$': '4',
+	'%': '5',
+	'^': '6',
+	'&': '7',
+	'*': '8',
+	'(': '9',
+	')': '0',
+	'_': '-',
+	'+': '=',
+	'{': '[',
+	'}': ']',
+	'|': '\\',
+	':': ';',
+	'"': '\'',
+	'<': ',',
+	'>': '.',
+	'?': '/',
+}
+
+// keyNames maps keysyms of keys that do not produce a printable ASCII character
+// to their backend (robotgo) names. The names are robotgo's, which the X11
+// headers know nothing about, so the table is written by hand.
+var keyNames = map[uint32]string{
+	0x0020: "space",
+
+	0xff08: "backspace",
+	0xff09: "tab",
+	0xff0d: "enter",
+	0xff1b: "esc",
+	0xffff: "delete",
+
+	0xff50: "home",
+	0xff51: "left",
+	0xff52: "up",
+	0xff53: "right",
+	0xff54: "down",
+	0xff55: "pageup",
+	0xff56: "pagedown",
+	0xff57: "end",
+
+	0xff61: "print",
+	0xfd1d: "printscreen", // No Mac support
+	0xff63: "insert",
+	0xff67: "menu", // Windows only
+	0xff7f: "num_lock",
+
+	// Keypad. Digits are typed as digits so they do not depend on the
+	// server's Num Lock state, the client has already applied its own.
+	0xff80: "space",
+	0xff89: "tab",
+	0xff8d: "num_enter",
+	0xff95: "home",
+	0xff96: "left",
+	0xff97: "up",
+	0xff98: "right",
+	0xff99: "down",
+	0xff9a: "pageup",
+	0xff9b: "pagedown",
+	0xff9c: "end",
+	0xff9e: "insert",
+	0xff9f: "delete",
+	0xffaa: "num*",
+	0xffab: "num+",
+	0xffac: "num.", // Separator
+	0xffad: "num-",
+	0xffae: "num.",
+	0xffaf: "num/",
+	0xffb0: "0",
+	0xffb1: "1",
+	0xffb2: "2",
+	0xffb3: "3",
+	0xffb4: "4",
+	0xffb5: "5",
+	0xffb6: "6",
+	0xffb7: "7",
+	0xffb8: "8",
+	0xffb9: "9",
+	0xffbd: "num_equal",
+	0xff0b: "num_clear", // Clear
+
+	0xffbe: "f1",
+	0xffbf: "f2",
+	0xffc0: "f3",
+	0xffc1: "f4",
+	0xffc2: "f5",
+	0xffc3: "f6",
+	0xffc4: "f7",
+	0xffc5: "f8",
+	0xffc6: "f9",
+	0xffc7: "f10",
+	0xffc8: "f11",
+	0xffc9: "f12",
+	0xffca: "f13",
+	0xffcb: "f14",
+	0xffcc: "f15",
+	0xffcd: "f16",
+	0xffce: "f17",
+	0xffcf: "f18",
+	0xffd0: "f19",
+	0xffd1: "f20",
+	0xffd2: "f21",
+	0xffd3: "f22",
+	0xffd4: "f23",
+	0xffd5: "f24",
+
+	0xffe1: "lshift", // left shift
+	0xffe2: "rshift", // right shift
+	0xffe3: "lctrl",  // left ctrl
+	0xffe4: "rctrl",  // right ctrl
+	0xffe5: "capslock",
+	0xffe7: "lcmd", // left meta
+	0xffe8: "rcmd", // right meta
+	0xffe9: "lalt", // left alt
+	0xffea: "ralt", // right alt
+	0xffeb: "lcmd", // left super
+	0xffec: "rcmd", // right super
+	0xfe03: "ralt", // AltGr
+
+	0x1008ff11: "audio_vol_down",
+	0x1008ff12: "audio_mute",
+	0x1008ff13: "audio_vol_up",
+	0x1008ff14: "audio_play",
+	0x1008ff15: "audio_stop",
+	0x1008ff16: "audio_prev",
+	0x1008ff17: "audio_next",
+	0x1008ff31: "audio_pause",
+}
+
+// deadKeys maps dead keysyms to the combining character they add. The X11
+// headers give no character for dead keys, so the table is written by hand.
+var deadKeys = map[uint32]rune{
+	0xfe50: 0x0300, // grave
+	0xfe51: 0x0301, // acute
+	0xfe52: 0x0302, // circumflex
+	0xfe53: 0x0303, // tilde
+	0xfe54: 0x0304, // macron
+	0xfe55: 0x0306, // breve
+	0xfe56: 0x0307, // abovedot
+	0xfe57: 0x0308, // diaeresis
+	0xfe58: 0x030a, // abovering
+	0xfe59: 0x030b, // doubleacute
+	0xfe5a: 0x030c, // caron
+	0xfe5b: 0x0327, // cedilla
+	0xfe5c: 0x0328, // ogonek
+	0xfe5d: 0x0345, // iota
+	0xfe5e: 0x3099, // voiced_sound
+	0xfe5f: 0x309a, // semivoiced_sound
+	0xfe60: 0x0323, // belowdot
+	0xfe61: 0x0309, // hook
+	0xfe62: 0x031b, // horn
+}
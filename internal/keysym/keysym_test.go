@@ -0,0 +1,51 @@
+package keysym
+
+import "testing"
+
+func TestPrintableASCII(t *testing.T) {
+	for c := rune(0x20); c <= 0x7e; c++ {
+		ks := uint32(c)
+		r, ok := Rune(ks)
+		if !ok || r != c {
+			t.Errorf("Rune(%#04x) = %q, %v, want %q", ks, r, ok, c)
+		}
+		want := string(c)
+		if c == ' ' {
+			want = "space"
+		}
+		name, ok := KeyName(ks)
+		if !ok || name != want {
+			t.Errorf("KeyName(%#04x) = %q, %v, want %q", ks, name, ok, want)
+		}
+	}
+}
+
+func TestUnicodeKeysyms(t *testing.T) {
+	r, ok := Rune(unicodeOffset + 0x20ac)
+	if !ok || r != '€' {
+		t.Errorf("Rune(U+20AC) = %q, %v", r, ok)
+	}
+	if _, ok := KeyName(unicodeOffset + 0x20ac); ok {
+		t.Error("U+20AC has a key name")
+	}
+}
+
+func TestDeadRune(t *testing.T) {
+	if r, ok := DeadRune(0xfe51); !ok || r != 0x0301 {
+		t.Errorf("DeadRune(dead_acute) = %#04x, %v", r, ok)
+	}
+	if _, ok := DeadRune('a'); ok {
+		t.Error("a is not a dead key")
+	}
+}
+
+func TestBaseKey(t *testing.T) {
+	for ks, want := range map[uint32]uint32{
+		'a': 'a', 'A': 'a', '1': '1', '!': '1', '@': '2', ')': '0',
+		'?': '/', '{': '[', '_': '-', '|': '\\', 0xffe1: 0xffe1,
+	} {
+		if got := BaseKey(ks); got != want {
+			t.Errorf("BaseKey(%#04x) = %#04x, want %#04x", ks, got, want)
+		}
+	}
+}
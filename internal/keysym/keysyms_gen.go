@@ -0,0 +1,2199 @@
+// Code generated by gen.go from keysymdef.h, XF86keysym.h; DO NOT EDIT.
+
+package keysym
+
+var keysyms = map[uint32]Keysym{
+	0x00000020: {"space", 0x0020},
+	0x00000021: {"exclam", 0x0021},
+	0x00000022: {"quotedbl", 0x0022},
+	0x00000023: {"numbersign", 0x0023},
+	0x00000024: {"dollar", 0x0024},
+	0x00000025: {"percent", 0x0025},
+	0x00000026: {"ampersand", 0x0026},
+	0x00000027: {"apostrophe", 0x0027},
+	0x00000028: {"parenleft", 0x0028},
+	0x00000029: {"parenright", 0x0029},
+	0x0000002a: {"asterisk", 0x002a},
+	0x0000002b: {"plus", 0x002b},
+	0x0000002c: {"comma", 0x002c},
+	0x0000002d: {"minus", 0x002d},
+	0x0000002e: {"period", 0x002e},
+	0x0000002f: {"slash", 0x002f},
+	0x00000030: {"0", 0x0030},
+	0x00000031: {"1", 0x0031},
+	0x00000032: {"2", 0x0032},
+	0x00000033: {"3", 0x0033},
+	0x00000034: {"4", 0x0034},
+	0x00000035: {"5", 0x0035},
+	0x00000036: {"6", 0x0036},
+	0x00000037: {"7", 0x0037},
+	0x00000038: {"8", 0x0038},
+	0x00000039: {"9", 0x0039},
+	0x0000003a: {"colon", 0x003a},
+	0x0000003b: {"semicolon", 0x003b},
+	0x0000003c: {"less", 0x003c},
+	0x0000003d: {"equal", 0x003d},
+	0x0000003e: {"greater", 0x003e},
+	0x0000003f: {"question", 0x003f},
+	0x00000040: {"at", 0x0040},
+	0x00000041: {"A", 0x0041},
+	0x00000042: {"B", 0x0042},
+	0x00000043: {"C", 0x0043},
+	0x00000044: {"D", 0x0044},
+	0x00000045: {"E", 0x0045},
+	0x00000046: {"F", 0x0046},
+	0x00000047: {"G", 0x0047},
+	0x00000048: {"H", 0x0048},
+	0x00000049: {"I", 0x0049},
+	0x0000004a: {"J", 0x004a},
+	0x0000004b: {"K", 0x004b},
+	0x0000004c: {"L", 0x004c},
+	0x0000004d: {"M", 0x004d},
+	0x0000004e: {"N", 0x004e},
+	0x0000004f: {"O", 0x004f},
+	0x00000050: {"P", 0x0050},
+	0x00000051: {"Q", 0x0051},
+	0x00000052: {"R", 0x0052},
+	0x00000053: {"S", 0x0053},
+	0x00000054: {"T", 0x0054},
+	0x00000055: {"U", 0x0055},
+	0x00000056: {"V", 0x0056},
+	0x00000057: {"W", 0x0057},
+	0x00000058: {"X", 0x0058},
+	0x00000059: {"Y", 0x0059},
+	0x0000005a: {"Z", 0x005a},
+	0x0000005b: {"bracketleft", 0x005b},
+	0x0000005c: {"backslash", 0x005c},
+	0x0000005d: {"bracketright", 0x005d},
+	0x0000005e: {"asciicircum", 0x005e},
+	0x0000005f: {"underscore", 0x005f},
+	0x00000060: {"grave", 0x0060},
+	0x00000061: {"a", 0x0061},
+	0x00000062: {"b", 0x0062},
+	0x00000063: {"c", 0x0063},
+	0x00000064: {"d", 0x0064},
+	0x00000065: {"e", 0x0065},
+	0x00000066: {"f", 0x0066},
+	0x00000067: {"g", 0x0067},
+	0x00000068: {"h", 0x0068},
+	0x00000069: {"i", 0x0069},
+	0x0000006a: {"j", 0x006a},
+	0x0000006b: {"k", 0x006b},
+	0x0000006c: {"l", 0x006c},
+	0x0000006d: {"m", 0x006d},
+	0x0000006e: {"n", 0x006e},
+	0x0000006f: {"o", 0x006f},
+	0x00000070: {"p", 0x0070},
+	0x00000071: {"q", 0x0071},
+	0x00000072: {"r", 0x0072},
+	0x00000073: {"s", 0x0073},
+	0x00000074: {"t", 0x0074},
+	0x00000075: {"u", 0x0075},
+	0x00000076: {"v", 0x0076},
+	0x00000077: {"w", 0x0077},
+	0x00000078: {"x", 0x0078},
+	0x00000079: {"y", 0x0079},
+	0x0000007a: {"z", 0x007a},
+	0x0000007b: {"braceleft", 0x007b},
+	0x0000007c: {"bar", 0x007c},
+	0x0000007d: {"braceright", 0x007d},
+	0x0000007e: {"asciitilde", 0x007e},
+	0x000000a0: {"nobreakspace", 0x00a0},
+	0x000000a1: {"exclamdown", 0x00a1},
+	0x000000a2: {"cent", 0x00a2},
+	0x000000a3: {"sterling", 0x00a3},
+	0x000000a4: {"currency", 0x00a4},
+	0x000000a5: {"yen", 0x00a5},
+	0x000000a6: {"brokenbar", 0x00a6},
+	0x000000a7: {"section", 0x00a7},
+	0x000000a8: {"diaeresis", 0x00a8},
+	0x000000a9: {"copyright", 0x00a9},
+	0x000000aa: {"ordfeminine", 0x00aa},
+	0x000000ab: {"guillemotleft", 0x00ab},
+	0x000000ac: {"notsign", 0x00ac},
+	0x000000ad: {"hyphen", 0x00ad},
+	0x000000ae: {"registered", 0x00ae},
+	0x000000af: {"macron", 0x00af},
+	0x000000b0: {"degree", 0x00b0},
+	0x000000b1: {"plusminus", 0x00b1},
+	0x000000b2: {"twosuperior", 0x00b2},
+	0x000000b3: {"threesuperior", 0x00b3},
+	0x000000b4: {"acute", 0x00b4},
+	0x000000b5: {"mu", 0x00b5},
+	0x000000b6: {"paragraph", 0x00b6},
+	0x000000b7: {"periodcentered", 0x00b7},
+	0x000000b8: {"cedilla", 0x00b8},
+	0x000000b9: {"onesuperior", 0x00b9},
+	0x000000ba: {"masculine", 0x00ba},
+	0x000000bb: {"guillemotright", 0x00bb},
+	0x000000bc: {"onequarter", 0x00bc},
+	0x000000bd: {"onehalf", 0x00bd},
+	0x000000be: {"threequarters", 0x00be},
+	0x000000bf: {"questiondown", 0x00bf},
+	0x000000c0: {"Agrave", 0x00c0},
+	0x000000c1: {"Aacute", 0x00c1},
+	0x000000c2: {"Acircumflex", 0x00c2},
+	0x000000c3: {"Atilde", 0x00c3},
+	0x000000c4: {"Adiaeresis", 0x00c4},
+	0x000000c5: {"Aring", 0x00c5},
+	0x000000c6: {"AE", 0x00c6},
+	0x000000c7: {"Ccedilla", 0x00c7},
+	0x000000c8: {"Egrave", 0x00c8},
+	0x000000c9: {"Eacute", 0x00c9},
+	0x000000ca: {"Ecircumflex", 0x00ca},
+	0x000000cb: {"Ediaeresis", 0x00cb},
+	0x000000cc: {"Igrave", 0x00cc},
+	0x000000cd: {"Iacute", 0x00cd},
+	0x000000ce: {"Icircumflex", 0x00ce},
+	0x000000cf: {"Idiaeresis", 0x00cf},
+	0x000000d0: {"ETH", 0x00d0},
+	0x000000d1: {"Ntilde", 0x00d1},
+	0x000000d2: {"Ograve", 0x00d2},
+	0x000000d3: {"Oacute", 0x00d3},
+	0x000000d4: {"Ocircumflex", 0x00d4},
+	0x000000d5: {"Otilde", 0x00d5},
+	0x000000d6: {"Odiaeresis", 0x00d6},
+	0x000000d7: {"multiply", 0x00d7},
+	0x000000d8: {"Oslash", 0x00d8},
+	0x000000d9: {"Ugrave", 0x00d9},
+	0x000000da: {"Uacute", 0x00da},
+	0x000000db: {"Ucircumflex", 0x00db},
+	0x000000dc: {"Udiaeresis", 0x00dc},
+	0x000000dd: {"Yacute", 0x00dd},
+	0x000000de: {"THORN", 0x00de},
+	0x000000df: {"ssharp", 0x00df},
+	0x000000e0: {"agrave", 0x00e0},
+	0x000000e1: {"aacute", 0x00e1},
+	0x000000e2: {"acircumflex", 0x00e2},
+	0x000000e3: {"atilde", 0x00e3},
+	0x000000e4: {"adiaeresis", 0x00e4},
+	0x000000e5: {"aring", 0x00e5},
+	0x000000e6: {"ae", 0x00e6},
+	0x000000e7: {"ccedilla", 0x00e7},
+	0x000000e8: {"egrave", 0x00e8},
+	0x000000e9: {"eacute", 0x00e9},
+	0x000000ea: {"ecircumflex", 0x00ea},
+	0x000000eb: {"ediaeresis", 0x00eb},
+	0x000000ec: {"igrave", 0x00ec},
+	0x000000ed: {"iacute", 0x00ed},
+	0x000000ee: {"icircumflex", 0x00ee},
+	0x000000ef: {"idiaeresis", 0x00ef},
+	0x000000f0: {"eth", 0x00f0},
+	0x000000f1: {"ntilde", 0x00f1},
+	0x000000f2: {"ograve", 0x00f2},
+	0x000000f3: {"oacute", 0x00f3},
+	0x000000f4: {"ocircumflex", 0x00f4},
+	0x000000f5: {"otilde", 0x00f5},
+	0x000000f6: {"odiaeresis", 0x00f6},
+	0x000000f7: {"division", 0x00f7},
+	0x000000f8: {"oslash", 0x00f8},
+	0x000000f9: {"ugrave", 0x00f9},
+	0x000000fa: {"uacute", 0x00fa},
+	0x000000fb: {"ucircumflex", 0x00fb},
+	0x000000fc: {"udiaeresis", 0x00fc},
+	0x000000fd: {"yacute", 0x00fd},
+	0x000000fe: {"thorn", 0x00fe},
+	0x000000ff: {"ydiaeresis", 0x00ff},
+	0x000001a1: {"Aogonek", 0x0104},
+	0x000001a2: {"breve", 0x02d8},
+	0x000001a3: {"Lstroke", 0x0141},
+	0x000001a5: {"Lcaron", 0x013d},
+	0x000001a6: {"Sacute", 0x015a},
+	0x000001a9: {"Scaron", 0x0160},
+	0x000001aa: {"Scedilla", 0x015e},
+	0x000001ab: {"Tcaron", 0x0164},
+	0x000001ac: {"Zacute", 0x0179},
+	0x000001ae: {"Zcaron", 0x017d},
+	0x000001af: {"Zabovedot", 0x017b},
+	0x000001b1: {"aogonek", 0x0105},
+	0x000001b2: {"ogonek", 0x02db},
+	0x000001b3: {"lstroke", 0x0142},
+	0x000001b5: {"lcaron", 0x013e},
+	0x000001b6: {"sacute", 0x015b},
+	0x000001b7: {"caron", 0x02c7},
+	0x000001b9: {"scaron", 0x0161},
+	0x000001ba: {"scedilla", 0x015f},
+	0x000001bb: {"tcaron", 0x0165},
+	0x000001bc: {"zacute", 0x017a},
+	0x000001bd: {"doubleacute", 0x02dd},
+	0x000001be: {"zcaron", 0x017e},
+	0x000001bf: {"zabovedot", 0x017c},
+	0x000001c0: {"Racute", 0x0154},
+	0x000001c3: {"Abreve", 0x0102},
+	0x000001c5: {"Lacute", 0x0139},
+	0x000001c6: {"Cacute", 0x0106},
+	0x000001c8: {"Ccaron", 0x010c},
+	0x000001ca: {"Eogonek", 0x0118},
+	0x000001cc: {"Ecaron", 0x011a},
+	0x000001cf: {"Dcaron", 0x010e},
+	0x000001d0: {"Dstroke", 0x0110},
+	0x000001d1: {"Nacute", 0x0143},
+	0x000001d2: {"Ncaron", 0x0147},
+	0x000001d5: {"Odoubleacute", 0x0150},
+	0x000001d8: {"Rcaron", 0x0158},
+	0x000001d9: {"Uring", 0x016e},
+	0x000001db: {"Udoubleacute", 0x0170},
+	0x000001de: {"Tcedilla", 0x0162},
+	0x000001e0: {"racute", 0x0155},
+	0x000001e3: {"abreve", 0x0103},
+	0x000001e5: {"lacute", 0x013a},
+	0x000001e6: {"cacute", 0x0107},
+	0x000001e8: {"ccaron", 0x010d},
+	0x000001ea: {"eogonek", 0x0119},
+	0x000001ec: {"ecaron", 0x011b},
+	0x000001ef: {"dcaron", 0x010f},
+	0x000001f0: {"dstroke", 0x0111},
+	0x000001f1: {"nacute", 0x0144},
+	0x000001f2: {"ncaron", 0x0148},
+	0x000001f5: {"odoubleacute", 0x0151},
+	0x000001f8: {"rcaron", 0x0159},
+	0x000001f9: {"uring", 0x016f},
+	0x000001fb: {"udoubleacute", 0x0171},
+	0x000001fe: {"tcedilla", 0x0163},
+	0x000001ff: {"abovedot", 0x02d9},
+	0x000002a1: {"Hstroke", 0x0126},
+	0x000002a6: {"Hcircumflex", 0x0124},
+	0x000002a9: {"Iabovedot", 0x0130},
+	0x000002ab: {"Gbreve", 0x011e},
+	0x000002ac: {"Jcircumflex", 0x0134},
+	0x000002b1: {"hstroke", 0x0127},
+	0x000002b6: {"hcircumflex", 0x0125},
+	0x000002b9: {"idotless", 0x0131},
+	0x000002bb: {"gbreve", 0x011f},
+	0x000002bc: {"jcircumflex", 0x0135},
+	0x000002c5: {"Cabovedot", 0x010a},
+	0x000002c6: {"Ccircumflex", 0x0108},
+	0x000002d5: {"Gabovedot", 0x0120},
+	0x000002d8: {"Gcircumflex", 0x011c},
+	0x000002dd: {"Ubreve", 0x016c},
+	0x000002de: {"Scircumflex", 0x015c},
+	0x000002e5: {"cabovedot", 0x010b},
+	0x000002e6: {"ccircumflex", 0x0109},
+	0x000002f5: {"gabovedot", 0x0121},
+	0x000002f8: {"gcircumflex", 0x011d},
+	0x000002fd: {"ubreve", 0x016d},
+	0x000002fe: {"scircumflex", 0x015d},
+	0x000003a2: {"kra", 0x0138},
+	0x000003a3: {"Rcedilla", 0x0156},
+	0x000003a5: {"Itilde", 0x0128},
+	0x000003a6: {"Lcedilla", 0x013b},
+	0x000003aa: {"Emacron", 0x0112},
+	0x000003ab: {"Gcedilla", 0x0122},
+	0x000003ac: {"Tslash", 0x0166},
+	0x000003b3: {"rcedilla", 0x0157},
+	0x000003b5: {"itilde", 0x0129},
+	0x000003b6: {"lcedilla", 0x013c},
+	0x000003ba: {"emacron", 0x0113},
+	0x000003bb: {"gcedilla", 0x0123},
+	0x000003bc: {"tslash", 0x0167},
+	0x000003bd: {"ENG", 0x014a},
+	0x000003bf: {"eng", 0x014b},
+	0x000003c0: {"Amacron", 0x0100},
+	0x000003c7: {"Iogonek", 0x012e},
+	0x000003cc: {"Eabovedot", 0x0116},
+	0x000003cf: {"Imacron", 0x012a},
+	0x000003d1: {"Ncedilla", 0x0145},
+	0x000003d2: {"Omacron", 0x014c},
+	0x000003d3: {"Kcedilla", 0x0136},
+	0x000003d9: {"Uogonek", 0x0172},
+	0x000003dd: {"Utilde", 0x0168},
+	0x000003de: {"Umacron", 0x016a},
+	0x000003e0: {"amacron", 0x0101},
+	0x000003e7: {"iogonek", 0x012f},
+	0x000003ec: {"eabovedot", 0x0117},
+	0x000003ef: {"imacron", 0x012b},
+	0x000003f1: {"ncedilla", 0x0146},
+	0x000003f2: {"omacron", 0x014d},
+	0x000003f3: {"kcedilla", 0x0137},
+	0x000003f9: {"uogonek", 0x0173},
+	0x000003fd: {"utilde", 0x0169},
+	0x000003fe: {"umacron", 0x016b},
+	0x0000047e: {"overline", 0x203e},
+	0x000004a1: {"kana_fullstop", 0x3002},
+	0x000004a2: {"kana_openingbracket", 0x300c},
+	0x000004a3: {"kana_closingbracket", 0x300d},
+	0x000004a4: {"kana_comma", 0x3001},
+	0x000004a5: {"kana_conjunctive", 0x30fb},
+	0x000004a6: {"kana_WO", 0x30f2},
+	0x000004a7: {"kana_a", 0x30a1},
+	0x000004a8: {"kana_i", 0x30a3},
+	0x000004a9: {"kana_u", 0x30a5},
+	0x000004aa: {"kana_e", 0x30a7},
+	0x000004ab: {"kana_o", 0x30a9},
+	0x000004ac: {"kana_ya", 0x30e3},
+	0x000004ad: {"kana_yu", 0x30e5},
+	0x000004ae: {"kana_yo", 0x30e7},
+	0x000004af: {"kana_tsu", 0x30c3},
+	0x000004b0: {"prolongedsound", 0x30fc},
+	0x000004b1: {"kana_A", 0x30a2},
+	0x000004b2: {"kana_I", 0x30a4},
+	0x000004b3: {"kana_U", 0x30a6},
+	0x000004b4: {"kana_E", 0x30a8},
+	0x000004b5: {"kana_O", 0x30aa},
+	0x000004b6: {"kana_KA", 0x30ab},
+	0x000004b7: {"kana_KI", 0x30ad},
+	0x000004b8: {"kana_KU", 0x30af},
+	0x000004b9: {"kana_KE", 0x30b1},
+	0x000004ba: {"kana_KO", 0x30b3},
+	0x000004bb: {"kana_SA", 0x30b5},
+	0x000004bc: {"kana_SHI", 0x30b7},
+	0x000004bd: {"kana_SU", 0x30b9},
+	0x000004be: {"kana_SE", 0x30bb},
+	0x000004bf: {"kana_SO", 0x30bd},
+	0x000004c0: {"kana_TA", 0x30bf},
+	0x000004c1: {"kana_CHI", 0x30c1},
+	0x000004c2: {"kana_TSU", 0x30c4},
+	0x000004c3: {"kana_TE", 0x30c6},
+	0x000004c4: {"kana_TO", 0x30c8},
+	0x000004c5: {"kana_NA", 0x30ca},
+	0x000004c6: {"kana_NI", 0x30cb},
+	0x000004c7: {"kana_NU", 0x30cc},
+	0x000004c8: {"kana_NE", 0x30cd},
+	0x000004c9: {"kana_NO", 0x30ce},
+	0x000004ca: {"kana_HA", 0x30cf},
+	0x000004cb: {"kana_HI", 0x30d2},
+	0x000004cc: {"kana_FU", 0x30d5},
+	0x000004cd: {"kana_HE", 0x30d8},
+	0x000004ce: {"kana_HO", 0x30db},
+	0x000004cf: {"kana_MA", 0x30de},
+	0x000004d0: {"kana_MI", 0x30df},
+	0x000004d1: {"kana_MU", 0x30e0},
+	0x000004d2: {"kana_ME", 0x30e1},
+	0x000004d3: {"kana_MO", 0x30e2},
+	0x000004d4: {"kana_YA", 0x30e4},
+	0x000004d5: {"kana_YU", 0x30e6},
+	0x000004d6: {"kana_YO", 0x30e8},
+	0x000004d7: {"kana_RA", 0x30e9},
+	0x000004d8: {"kana_RI", 0x30ea},
+	0x000004d9: {"kana_RU", 0x30eb},
+	0x000004da: {"kana_RE", 0x30ec},
+	0x000004db: {"kana_RO", 0x30ed},
+	0x000004dc: {"kana_WA", 0x30ef},
+	0x000004dd: {"kana_N", 0x30f3},
+	0x000004de: {"voicedsound", 0x309b},
+	0x000004df: {"semivoicedsound", 0x309c},
+	0x000005ac: {"Arabic_comma", 0x060c},
+	0x000005bb: {"Arabic_semicolon", 0x061b},
+	0x000005bf: {"Arabic_question_mark", 0x061f},
+	0x000005c1: {"Arabic_hamza", 0x0621},
+	0x000005c2: {"Arabic_maddaonalef", 0x0622},
+	0x000005c3: {"Arabic_hamzaonalef", 0x0623},
+	0x000005c4: {"Arabic_hamzaonwaw", 0x0624},
+	0x000005c5: {"Arabic_hamzaunderalef", 0x0625},
+	0x000005c6: {"Arabic_hamzaonyeh", 0x0626},
+	0x000005c7: {"Arabic_alef", 0x0627},
+	0x000005c8: {"Arabic_beh", 0x0628},
+	0x000005c9: {"Arabic_tehmarbuta", 0x0629},
+	0x000005ca: {"Arabic_teh", 0x062a},
+	0x000005cb: {"Arabic_theh", 0x062b},
+	0x000005cc: {"Arabic_jeem", 0x062c},
+	0x000005cd: {"Arabic_hah", 0x062d},
+	0x000005ce: {"Arabic_khah", 0x062e},
+	0x000005cf: {"Arabic_dal", 0x062f},
+	0x000005d0: {"Arabic_thal", 0x0630},
+	0x000005d1: {"Arabic_ra", 0x0631},
+	0x000005d2: {"Arabic_zain", 0x0632},
+	0x000005d3: {"Arabic_seen", 0x0633},
+	0x000005d4: {"Arabic_sheen", 0x0634},
+	0x000005d5: {"Arabic_sad", 0x0635},
+	0x000005d6: {"Arabic_dad", 0x0636},
+	0x000005d7: {"Arabic_tah", 0x0637},
+	0x000005d8: {"Arabic_zah", 0x0638},
+	0x000005d9: {"Arabic_ain", 0x0639},
+	0x000005da: {"Arabic_ghain", 0x063a},
+	0x000005e0: {"Arabic_tatweel", 0x0640},
+	0x000005e1: {"Arabic_feh", 0x0641},
+	0x000005e2: {"Arabic_qaf", 0x0642},
+	0x000005e3: {"Arabic_kaf", 0x0643},
+	0x000005e4: {"Arabic_lam", 0x0644},
+	0x000005e5: {"Arabic_meem", 0x0645},
+	0x000005e6: {"Arabic_noon", 0x0646},
+	0x000005e7: {"Arabic_ha", 0x0647},
+	0x000005e8: {"Arabic_waw", 0x0648},
+	0x000005e9: {"Arabic_alefmaksura", 0x0649},
+	0x000005ea: {"Arabic_yeh", 0x064a},
+	0x000005eb: {"Arabic_fathatan", 0x064b},
+	0x000005ec: {"Arabic_dammatan", 0x064c},
+	0x000005ed: {"Arabic_kasratan", 0x064d},
+	0x000005ee: {"Arabic_fatha", 0x064e},
+	0x000005ef: {"Arabic_damma", 0x064f},
+	0x000005f0: {"Arabic_kasra", 0x0650},
+	0x000005f1: {"Arabic_shadda", 0x0651},
+	0x000005f2: {"Arabic_sukun", 0x0652},
+	0x000006a1: {"Serbian_dje", 0x0452},
+	0x000006a2: {"Macedonia_gje", 0x0453},
+	0x000006a3: {"Cyrillic_io", 0x0451},
+	0x000006a4: {"Ukrainian_ie", 0x0454},
+	0x000006a5: {"Macedonia_dse", 0x0455},
+	0x000006a6: {"Ukrainian_i", 0x0456},
+	0x000006a7: {"Ukrainian_yi", 0x0457},
+	0x000006a8: {"Cyrillic_je", 0x0458},
+	0x000006a9: {"Cyrillic_lje", 0x0459},
+	0x000006aa: {"Cyrillic_nje", 0x045a},
+	0x000006ab: {"Serbian_tshe", 0x045b},
+	0x000006ac: {"Macedonia_kje", 0x045c},
+	0x000006ad: {"Ukrainian_ghe_with_upturn", 0x0491},
+	0x000006ae: {"Byelorussian_shortu", 0x045e},
+	0x000006af: {"Cyrillic_dzhe", 0x045f},
+	0x000006b0: {"numerosign", 0x2116},
+	0x000006b1: {"Serbian_DJE", 0x0402},
+	0x000006b2: {"Macedonia_GJE", 0x0403},
+	0x000006b3: {"Cyrillic_IO", 0x0401},
+	0x000006b4: {"Ukrainian_IE", 0x0404},
+	0x000006b5: {"Macedonia_DSE", 0x0405},
+	0x000006b6: {"Ukrainian_I", 0x0406},
+	0x000006b7: {"Ukrainian_YI", 0x0407},
+	0x000006b8: {"Cyrillic_JE", 0x0408},
+	0x000006b9: {"Cyrillic_LJE", 0x0409},
+	0x000006ba: {"Cyrillic_NJE", 0x040a},
+	0x000006bb: {"Serbian_TSHE", 0x040b},
+	0x000006bc: {"Macedonia_KJE", 0x040c},
+	0x000006bd: {"Ukrainian_GHE_WITH_UPTURN", 0x0490},
+	0x000006be: {"Byelorussian_SHORTU", 0x040e},
+	0x000006bf: {"Cyrillic_DZHE", 0x040f},
+	0x000006c0: {"Cyrillic_yu", 0x044e},
+	0x000006c1: {"Cyrillic_a", 0x0430},
+	0x000006c2: {"Cyrillic_be", 0x0431},
+	0x000006c3: {"Cyrillic_tse", 0x0446},
+	0x000006c4: {"Cyrillic_de", 0x0434},
+	0x000006c5: {"Cyrillic_ie", 0x0435},
+	0x000006c6: {"Cyrillic_ef", 0x0444},
+	0x000006c7: {"Cyrillic_ghe", 0x0433},
+	0x000006c8: {"Cyrillic_ha", 0x0445},
+	0x000006c9: {"Cyrillic_i", 0x0438},
+	0x000006ca: {"Cyrillic_shorti", 0x0439},
+	0x000006cb: {"Cyrillic_ka", 0x043a},
+	0x000006cc: {"Cyrillic_el", 0x043b},
+	0x000006cd: {"Cyrillic_em", 0x043c},
+	0x000006ce: {"Cyrillic_en", 0x043d},
+	0x000006cf: {"Cyrillic_o", 0x043e},
+	0x000006d0: {"Cyrillic_pe", 0x043f},
+	0x000006d1: {"Cyrillic_ya", 0x044f},
+	0x000006d2: {"Cyrillic_er", 0x0440},
+	0x000006d3: {"Cyrillic_es", 0x0441},
+	0x000006d4: {"Cyrillic_te", 0x0442},
+	0x000006d5: {"Cyrillic_u", 0x0443},
+	0x000006d6: {"Cyrillic_zhe", 0x0436},
+	0x000006d7: {"Cyrillic_ve", 0x0432},
+	0x000006d8: {"Cyrillic_softsign", 0x044c},
+	0x000006d9: {"Cyrillic_yeru", 0x044b},
+	0x000006da: {"Cyrillic_ze", 0x0437},
+	0x000006db: {"Cyrillic_sha", 0x0448},
+	0x000006dc: {"Cyrillic_e", 0x044d},
+	0x000006dd: {"Cyrillic_shcha", 0x0449},
+	0x000006de: {"Cyrillic_che", 0x0447},
+	0x000006df: {"Cyrillic_hardsign", 0x044a},
+	0x000006e0: {"Cyrillic_YU", 0x042e},
+	0x000006e1: {"Cyrillic_A", 0x0410},
+	0x000006e2: {"Cyrillic_BE", 0x0411},
+	0x000006e3: {"Cyrillic_TSE", 0x0426},
+	0x000006e4: {"Cyrillic_DE", 0x0414},
+	0x000006e5: {"Cyrillic_IE", 0x0415},
+	0x000006e6: {"Cyrillic_EF", 0x0424},
+	0x000006e7: {"Cyrillic_GHE", 0x0413},
+	0x000006e8: {"Cyrillic_HA", 0x0425},
+	0x000006e9: {"Cyrillic_I", 0x0418},
+	0x000006ea: {"Cyrillic_SHORTI", 0x0419},
+	0x000006eb: {"Cyrillic_KA", 0x041a},
+	0x000006ec: {"Cyrillic_EL", 0x041b},
+	0x000006ed: {"Cyrillic_EM", 0x041c},
+	0x000006ee: {"Cyrillic_EN", 0x041d},
+	0x000006ef: {"Cyrillic_O", 0x041e},
+	0x000006f0: {"Cyrillic_PE", 0x041f},
+	0x000006f1: {"Cyrillic_YA", 0x042f},
+	0x000006f2: {"Cyrillic_ER", 0x0420},
+	0x000006f3: {"Cyrillic_ES", 0x0421},
+	0x000006f4: {"Cyrillic_TE", 0x0422},
+	0x000006f5: {"Cyrillic_U", 0x0423},
+	0x000006f6: {"Cyrillic_ZHE", 0x0416},
+	0x000006f7: {"Cyrillic_VE", 0x0412},
+	0x000006f8: {"Cyrillic_SOFTSIGN", 0x042c},
+	0x000006f9: {"Cyrillic_YERU", 0x042b},
+	0x000006fa: {"Cyrillic_ZE", 0x0417},
+	0x000006fb: {"Cyrillic_SHA", 0x0428},
+	0x000006fc: {"Cyrillic_E", 0x042d},
+	0x000006fd: {"Cyrillic_SHCHA", 0x0429},
+	0x000006fe: {"Cyrillic_CHE", 0x0427},
+	0x000006ff: {"Cyrillic_HARDSIGN", 0x042a},
+	0x000007a1: {"Greek_ALPHAaccent", 0x0386},
+	0x000007a2: {"Greek_EPSILONaccent", 0x0388},
+	0x000007a3: {"Greek_ETAaccent", 0x0389},
+	0x000007a4: {"Greek_IOTAaccent", 0x038a},
+	0x000007a5: {"Greek_IOTAdieresis", 0x03aa},
+	0x000007a7: {"Greek_OMICRONaccent", 0x038c},
+	0x000007a8: {"Greek_UPSILONaccent", 0x038e},
+	0x000007a9: {"Greek_UPSILONdieresis", 0x03ab},
+	0x000007ab: {"Greek_OMEGAaccent", 0x038f},
+	0x000007ae: {"Greek_accentdieresis", 0x0385},
+	0x000007af: {"Greek_horizbar", 0x2015},
+	0x000007b1: {"Greek_alphaaccent", 0x03ac},
+	0x000007b2: {"Greek_epsilonaccent", 0x03ad},
+	0x000007b3: {"Greek_etaaccent", 0x03ae},
+	0x000007b4: {"Greek_iotaaccent", 0x03af},
+	0x000007b5: {"Greek_iotadieresis", 0x03ca},
+	0x000007b6: {"Greek_iotaaccentdieresis", 0x0390},
+	0x000007b7: {"Greek_omicronaccent", 0x03cc},
+	0x000007b8: {"Greek_upsilonaccent", 0x03cd},
+	0x000007b9: {"Greek_upsilondieresis", 0x03cb},
+	0x000007ba: {"Greek_upsilonaccentdieresis", 0x03b0},
+	0x000007bb: {"Greek_omegaaccent", 0x03ce},
+	0x000007c1: {"Greek_ALPHA", 0x0391},
+	0x000007c2: {"Greek_BETA", 0x0392},
+	0x000007c3: {"Greek_GAMMA", 0x0393},
+	0x000007c4: {"Greek_DELTA", 0x0394},
+	0x000007c5: {"Greek_EPSILON", 0x0395},
+	0x000007c6: {"Greek_ZETA", 0x0396},
+	0x000007c7: {"Greek_ETA", 0x0397},
+	0x000007c8: {"Greek_THETA", 0x0398},
+	0x000007c9: {"Greek_IOTA", 0x0399},
+	0x000007ca: {"Greek_KAPPA", 0x039a},
+	0x000007cb: {"Greek_LAMDA", 0x039b},
+	0x000007cc: {"Greek_MU", 0x039c},
+	0x000007cd: {"Greek_NU", 0x039d},
+	0x000007ce: {"Greek_XI", 0x039e},
+	0x000007cf: {"Greek_OMICRON", 0x039f},
+	0x000007d0: {"Greek_PI", 0x03a0},
+	0x000007d1: {"Greek_RHO", 0x03a1},
+	0x000007d2: {"Greek_SIGMA", 0x03a3},
+	0x000007d4: {"Greek_TAU", 0x03a4},
+	0x000007d5: {"Greek_UPSILON", 0x03a5},
+	0x000007d6: {"Greek_PHI", 0x03a6},
+	0x000007d7: {"Greek_CHI", 0x03a7},
+	0x000007d8: {"Greek_PSI", 0x03a8},
+	0x000007d9: {"Greek_OMEGA", 0x03a9},
+	0x000007e1: {"Greek_alpha", 0x03b1},
+	0x000007e2: {"Greek_beta", 0x03b2},
+	0x000007e3: {"Greek_gamma", 0x03b3},
+	0x000007e4: {"Greek_delta", 0x03b4},
+	0x000007e5: {"Greek_epsilon", 0x03b5},
+	0x000007e6: {"Greek_zeta", 0x03b6},
+	0x000007e7: {"Greek_eta", 0x03b7},
+	0x000007e8: {"Greek_theta", 0x03b8},
+	0x000007e9: {"Greek_iota", 0x03b9},
+	0x000007ea: {"Greek_kappa", 0x03ba},
+	0x000007eb: {"Greek_lamda", 0x03bb},
+	0x000007ec: {"Greek_mu", 0x03bc},
+	0x000007ed: {"Greek_nu", 0x03bd},
+	0x000007ee: {"Greek_xi", 0x03be},
+	0x000007ef: {"Greek_omicron", 0x03bf},
+	0x000007f0: {"Greek_pi", 0x03c0},
+	0x000007f1: {"Greek_rho", 0x03c1},
+	0x000007f2: {"Greek_sigma", 0x03c3},
+	0x000007f3: {"Greek_finalsmallsigma", 0x03c2},
+	0x000007f4: {"Greek_tau", 0x03c4},
+	0x000007f5: {"Greek_upsilon", 0x03c5},
+	0x000007f6: {"Greek_phi", 0x03c6},
+	0x000007f7: {"Greek_chi", 0x03c7},
+	0x000007f8: {"Greek_psi", 0x03c8},
+	0x000007f9: {"Greek_omega", 0x03c9},
+	0x000008a1: {"leftradical", 0x23b7},
+	0x000008a2: {"topleftradical", 0x250c},
+	0x000008a3: {"horizconnector", 0x2500},
+	0x000008a4: {"topintegral", 0x2320},
+	0x000008a5: {"botintegral", 0x2321},
+	0x000008a6: {"vertconnector", 0x2502},
+	0x000008a7: {"topleftsqbracket", 0x23a1},
+	0x000008a8: {"botleftsqbracket", 0x23a3},
+	0x000008a9: {"toprightsqbracket", 0x23a4},
+	0x000008aa: {"botrightsqbracket", 0x23a6},
+	0x000008ab: {"topleftparens", 0x239b},
+	0x000008ac: {"botleftparens", 0x239d},
+	0x000008ad: {"toprightparens", 0x239e},
+	0x000008ae: {"botrightparens", 0x23a0},
+	0x000008af: {"leftmiddlecurlybrace", 0x23a8},
+	0x000008b0: {"rightmiddlecurlybrace", 0x23ac},
+	0x000008b1: {"topleftsummation", 0x0000},
+	0x000008b2: {"botleftsummation", 0x0000},
+	0x000008b3: {"topvertsummationconnector", 0x0000},
+	0x000008b4: {"botvertsummationconnector", 0x0000},
+	0x000008b5: {"toprightsummation", 0x0000},
+	0x000008b6: {"botrightsummation", 0x0000},
+	0x000008b7: {"rightmiddlesummation", 0x0000},
+	0x000008bc: {"lessthanequal", 0x2264},
+	0x000008bd: {"notequal", 0x2260},
+	0x000008be: {"greaterthanequal", 0x2265},
+	0x000008bf: {"integral", 0x222b},
+	0x000008c0: {"therefore", 0x2234},
+	0x000008c1: {"variation", 0x221d},
+	0x000008c2: {"infinity", 0x221e},
+	0x000008c5: {"nabla", 0x2207},
+	0x000008c8: {"approximate", 0x223c},
+	0x000008c9: {"similarequal", 0x2243},
+	0x000008cd: {"ifonlyif", 0x21d4},
+	0x000008ce: {"implies", 0x21d2},
+	0x000008cf: {"identical", 0x2261},
+	0x000008d6: {"radical", 0x221a},
+	0x000008da: {"includedin", 0x2282},
+	0x000008db: {"includes", 0x2283},
+	0x000008dc: {"intersection", 0x2229},
+	0x000008dd: {"union", 0x222a},
+	0x000008de: {"logicaland", 0x2227},
+	0x000008df: {"logicalor", 0x2228},
+	0x000008ef: {"partialderivative", 0x2202},
+	0x000008f6: {"function", 0x0192},
+	0x000008fb: {"leftarrow", 0x2190},
+	0x000008fc: {"uparrow", 0x2191},
+	0x000008fd: {"rightarrow", 0x2192},
+	0x000008fe: {"downarrow", 0x2193},
+	0x000009df: {"blank", 0x0000},
+	0x000009e0: {"soliddiamond", 0x25c6},
+	0x000009e1: {"checkerboard", 0x2592},
+	0x000009e2: {"ht", 0x2409},
+	0x000009e3: {"ff", 0x240c},
+	0x000009e4: {"cr", 0x240d},
+	0x000009e5: {"lf", 0x240a},
+	0x000009e8: {"nl", 0x2424},
+	0x000009e9: {"vt", 0x240b},
+	0x000009ea: {"lowrightcorner", 0x2518},
+	0x000009eb: {"uprightcorner", 0x2510},
+	0x000009ec: {"upleftcorner", 0x250c},
+	0x000009ed: {"lowleftcorner", 0x2514},
+	0x000009ee: {"crossinglines", 0x253c},
+	0x000009ef: {"horizlinescan1", 0x23ba},
+	0x000009f0: {"horizlinescan3", 0x23bb},
+	0x000009f1: {"horizlinescan5", 0x2500},
+	0x000009f2: {"horizlinescan7", 0x23bc},
+	0x000009f3: {"horizlinescan9", 0x23bd},
+	0x000009f4: {"leftt", 0x251c},
+	0x000009f5: {"rightt", 0x2524},
+	0x000009f6: {"bott", 0x2534},
+	0x000009f7: {"topt", 0x252c},
+	0x000009f8: {"vertbar", 0x2502},
+	0x00000aa1: {"emspace", 0x2003},
+	0x00000aa2: {"enspace", 0x2002},
+	0x00000aa3: {"em3space", 0x2004},
+	0x00000aa4: {"em4space", 0x2005},
+	0x00000aa5: {"digitspace", 0x2007},
+	0x00000aa6: {"punctspace", 0x2008},
+	0x00000aa7: {"thinspace", 0x2009},
+	0x00000aa8: {"hairspace", 0x200a},
+	0x00000aa9: {"emdash", 0x2014},
+	0x00000aaa: {"endash", 0x2013},
+	0x00000aac: {"signifblank", 0x2423},
+	0x00000aae: {"ellipsis", 0x2026},
+	0x00000aaf: {"doubbaselinedot", 0x2025},
+	0x00000ab0: {"onethird", 0x2153},
+	0x00000ab1: {"twothirds", 0x2154},
+	0x00000ab2: {"onefifth", 0x2155},
+	0x00000ab3: {"twofifths", 0x2156},
+	0x00000ab4: {"threefifths", 0x2157},
+	0x00000ab5: {"fourfifths", 0x2158},
+	0x00000ab6: {"onesixth", 0x2159},
+	0x00000ab7: {"fivesixths", 0x215a},
+	0x00000ab8: {"careof", 0x2105},
+	0x00000abb: {"figdash", 0x2012},
+	0x00000abc: {"leftanglebracket", 0x2329},
+	0x00000abd: {"decimalpoint", 0x002e},
+	0x00000abe: {"rightanglebracket", 0x232a},
+	0x00000abf: {"marker", 0x0000},
+	0x00000ac3: {"oneeighth", 0x215b},
+	0x00000ac4: {"threeeighths", 0x215c},
+	0x00000ac5: {"fiveeighths", 0x215d},
+	0x00000ac6: {"seveneighths", 0x215e},
+	0x00000ac9: {"trademark", 0x2122},
+	0x00000aca: {"signaturemark", 0x2613},
+	0x00000acb: {"trademarkincircle", 0x0000},
+	0x00000acc: {"leftopentriangle", 0x25c1},
+	0x00000acd: {"rightopentriangle", 0x25b7},
+	0x00000ace: {"emopencircle", 0x25cb},
+	0x00000acf: {"emopenrectangle", 0x25af},
+	0x00000ad0: {"leftsinglequotemark", 0x2018},
+	0x00000ad1: {"rightsinglequotemark", 0x2019},
+	0x00000ad2: {"leftdoublequotemark", 0x201c},
+	0x00000ad3: {"rightdoublequotemark", 0x201d},
+	0x00000ad4: {"prescription", 0x211e},
+	0x00000ad5: {"permille", 0x2030},
+	0x00000ad6: {"minutes", 0x2032},
+	0x00000ad7: {"seconds", 0x2033},
+	0x00000ad9: {"latincross", 0x271d},
+	0x00000ada: {"hexagram", 0x0000},
+	0x00000adb: {"filledrectbullet", 0x25ac},
+	0x00000adc: {"filledlefttribullet", 0x25c0},
+	0x00000add: {"filledrighttribullet", 0x25b6},
+	0x00000ade: {"emfilledcircle", 0x25cf},
+	0x00000adf: {"emfilledrect", 0x25ae},
+	0x00000ae0: {"enopencircbullet", 0x25e6},
+	0x00000ae1: {"enopensquarebullet", 0x25ab},
+	0x00000ae2: {"openrectbullet", 0x25ad},
+	0x00000ae3: {"opentribulletup", 0x25b3},
+	0x00000ae4: {"opentribulletdown", 0x25bd},
+	0x00000ae5: {"openstar", 0x2606},
+	0x00000ae6: {"enfilledcircbullet", 0x2022},
+	0x00000ae7: {"enfilledsqbullet", 0x25aa},
+	0x00000ae8: {"filledtribulletup", 0x25b2},
+	0x00000ae9: {"filledtribulletdown", 0x25bc},
+	0x00000aea: {"leftpointer", 0x261c},
+	0x00000aeb: {"rightpointer", 0x261e},
+	0x00000aec: {"club", 0x2663},
+	0x00000aed: {"diamond", 0x2666},
+	0x00000aee: {"heart", 0x2665},
+	0x00000af0: {"maltesecross", 0x2720},
+	0x00000af1: {"dagger", 0x2020},
+	0x00000af2: {"doubledagger", 0x2021},
+	0x00000af3: {"checkmark", 0x2713},
+	0x00000af4: {"ballotcross", 0x2717},
+	0x00000af5: {"musicalsharp", 0x266f},
+	0x00000af6: {"musicalflat", 0x266d},
+	0x00000af7: {"malesymbol", 0x2642},
+	0x00000af8: {"femalesymbol", 0x2640},
+	0x00000af9: {"telephone", 0x260e},
+	0x00000afa: {"telephonerecorder", 0x2315},
+	0x00000afb: {"phonographcopyright", 0x2117},
+	0x00000afc: {"caret", 0x2038},
+	0x00000afd: {"singlelowquotemark", 0x201a},
+	0x00000afe: {"doublelowquotemark", 0x201e},
+	0x00000aff: {"cursor", 0x0000},
+	0x00000ba3: {"leftcaret", 0x003c},
+	0x00000ba6: {"rightcaret", 0x003e},
+	0x00000ba8: {"downcaret", 0x2228},
+	0x00000ba9: {"upcaret", 0x2227},
+	0x00000bc0: {"overbar", 0x00af},
+	0x00000bc2: {"downtack", 0x22a4},
+	0x00000bc3: {"upshoe", 0x2229},
+	0x00000bc4: {"downstile", 0x230a},
+	0x00000bc6: {"underbar", 0x005f},
+	0x00000bca: {"jot", 0x2218},
+	0x00000bcc: {"quad", 0x2395},
+	0x00000bce: {"uptack", 0x22a5},
+	0x00000bcf: {"circle", 0x25cb},
+	0x00000bd3: {"upstile", 0x2308},
+	0x00000bd6: {"downshoe", 0x222a},
+	0x00000bd8: {"rightshoe", 0x2283},
+	0x00000bda: {"leftshoe", 0x2282},
+	0x00000bdc: {"lefttack", 0x22a3},
+	0x00000bfc: {"righttack", 0x22a2},
+	0x00000cdf: {"hebrew_doublelowline", 0x2017},
+	0x00000ce0: {"hebrew_aleph", 0x05d0},
+	0x00000ce1: {"hebrew_bet", 0x05d1},
+	0x00000ce2: {"hebrew_gimel", 0x05d2},
+	0x00000ce3: {"hebrew_dalet", 0x05d3},
+	0x00000ce4: {"hebrew_he", 0x05d4},
+	0x00000ce5: {"hebrew_waw", 0x05d5},
+	0x00000ce6: {"hebrew_zain", 0x05d6},
+	0x00000ce7: {"hebrew_chet", 0x05d7},
+	0x00000ce8: {"hebrew_tet", 0x05d8},
+	0x00000ce9: {"hebrew_yod", 0x05d9},
+	0x00000cea: {"hebrew_finalkaph", 0x05da},
+	0x00000ceb: {"hebrew_kaph", 0x05db},
+	0x00000cec: {"hebrew_lamed", 0x05dc},
+	0x00000ced: {"hebrew_finalmem", 0x05dd},
+	0x00000cee: {"hebrew_mem", 0x05de},
+	0x00000cef: {"hebrew_finalnun", 0x05df},
+	0x00000cf0: {"hebrew_nun", 0x05e0},
+	0x00000cf1: {"hebrew_samech", 0x05e1},
+	0x00000cf2: {"hebrew_ayin", 0x05e2},
+	0x00000cf3: {"hebrew_finalpe", 0x05e3},
+	0x00000cf4: {"hebrew_pe", 0x05e4},
+	0x00000cf5: {"hebrew_finalzade", 0x05e5},
+	0x00000cf6: {"hebrew_zade", 0x05e6},
+	0x00000cf7: {"hebrew_qoph", 0x05e7},
+	0x00000cf8: {"hebrew_resh", 0x05e8},
+	0x00000cf9: {"hebrew_shin", 0x05e9},
+	0x00000cfa: {"hebrew_taw", 0x05ea},
+	0x00000da1: {"Thai_kokai", 0x0e01},
+	0x00000da2: {"Thai_khokhai", 0x0e02},
+	0x00000da3: {"Thai_khokhuat", 0x0e03},
+	0x00000da4: {"Thai_khokhwai", 0x0e04},
+	0x00000da5: {"Thai_khokhon", 0x0e05},
+	0x00000da6: {"Thai_khorakhang", 0x0e06},
+	0x00000da7: {"Thai_ngongu", 0x0e07},
+	0x00000da8: {"Thai_chochan", 0x0e08},
+	0x00000da9: {"Thai_choching", 0x0e09},
+	0x00000daa: {"Thai_chochang", 0x0e0a},
+	0x00000dab: {"Thai_soso", 0x0e0b},
+	0x00000dac: {"Thai_chochoe", 0x0e0c},
+	0x00000dad: {"Thai_yoying", 0x0e0d},
+	0x00000dae: {"Thai_dochada", 0x0e0e},
+	0x00000daf: {"Thai_topatak", 0x0e0f},
+	0x00000db0: {"Thai_thothan", 0x0e10},
+	0x00000db1: {"Thai_thonangmontho", 0x0e11},
+	0x00000db2: {"Thai_thophuthao", 0x0e12},
+	0x00000db3: {"Thai_nonen", 0x0e13},
+	0x00000db4: {"Thai_dodek", 0x0e14},
+	0x00000db5: {"Thai_totao", 0x0e15},
+	0x00000db6: {"Thai_thothung", 0x0e16},
+	0x00000db7: {"Thai_thothahan", 0x0e17},
+	0x00000db8: {"Thai_thothong", 0x0e18},
+	0x00000db9: {"Thai_nonu", 0x0e19},
+	0x00000dba: {"Thai_bobaimai", 0x0e1a},
+	0x00000dbb: {"Thai_popla", 0x0e1b},
+	0x00000dbc: {"Thai_phophung", 0x0e1c},
+	0x00000dbd: {"Thai_fofa", 0x0e1d},
+	0x00000dbe: {"Thai_phophan", 0x0e1e},
+	0x00000dbf: {"Thai_fofan", 0x0e1f},
+	0x00000dc0: {"Thai_phosamphao", 0x0e20},
+	0x00000dc1: {"Thai_moma", 0x0e21},
+	0x00000dc2: {"Thai_yoyak", 0x0e22},
+	0x00000dc3: {"Thai_rorua", 0x0e23},
+	0x00000dc4: {"Thai_ru", 0x0e24},
+	0x00000dc5: {"Thai_loling", 0x0e25},
+	0x00000dc6: {"Thai_lu", 0x0e26},
+	0x00000dc7: {"Thai_wowaen", 0x0e27},
+	0x00000dc8: {"Thai_sosala", 0x0e28},
+	0x00000dc9: {"Thai_sorusi", 0x0e29},
+	0x00000dca: {"Thai_sosua", 0x0e2a},
+	0x00000dcb: {"Thai_hohip", 0x0e2b},
+	0x00000dcc: {"Thai_lochula", 0x0e2c},
+	0x00000dcd: {"Thai_oang", 0x0e2d},
+	0x00000dce: {"Thai_honokhuk", 0x0e2e},
+	0x00000dcf: {"Thai_paiyannoi", 0x0e2f},
+	0x00000dd0: {"Thai_saraa", 0x0e30},
+	0x00000dd1: {"Thai_maihanakat", 0x0e31},
+	0x00000dd2: {"Thai_saraaa", 0x0e32},
+	0x00000dd3: {"Thai_saraam", 0x0e33},
+	0x00000dd4: {"Thai_sarai", 0x0e34},
+	0x00000dd5: {"Thai_saraii", 0x0e35},
+	0x00000dd6: {"Thai_saraue", 0x0e36},
+	0x00000dd7: {"Thai_sarauee", 0x0e37},
+	0x00000dd8: {"Thai_sarau", 0x0e38},
+	0x00000dd9: {"Thai_sarauu", 0x0e39},
+	0x00000dda: {"Thai_phinthu", 0x0e3a},
+	0x00000dde: {"Thai_maihanakat_maitho", 0x0000},
+	0x00000ddf: {"Thai_baht", 0x0e3f},
+	0x00000de0: {"Thai_sarae", 0x0e40},
+	0x00000de1: {"Thai_saraae", 0x0e41},
+	0x00000de2: {"Thai_sarao", 0x0e42},
+	0x00000de3: {"Thai_saraaimaimuan", 0x0e43},
+	0x00000de4: {"Thai_saraaimaimalai", 0x0e44},
+	0x00000de5: {"Thai_lakkhangyao", 0x0e45},
+	0x00000de6: {"Thai_maiyamok", 0x0e46},
+	0x00000de7: {"Thai_maitaikhu", 0x0e47},
+	0x00000de8: {"Thai_maiek", 0x0e48},
+	0x00000de9: {"Thai_maitho", 0x0e49},
+	0x00000dea: {"Thai_maitri", 0x0e4a},
+	0x00000deb: {"Thai_maichattawa", 0x0e4b},
+	0x00000dec: {"Thai_thanthakhat", 0x0e4c},
+	0x00000ded: {"Thai_nikhahit", 0x0e4d},
+	0x00000df0: {"Thai_leksun", 0x0e50},
+	0x00000df1: {"Thai_leknung", 0x0e51},
+	0x00000df2: {"Thai_leksong", 0x0e52},
+	0x00000df3: {"Thai_leksam", 0x0e53},
+	0x00000df4: {"Thai_leksi", 0x0e54},
+	0x00000df5: {"Thai_lekha", 0x0e55},
+	0x00000df6: {"Thai_lekhok", 0x0e56},
+	0x00000df7: {"Thai_lekchet", 0x0e57},
+	0x00000df8: {"Thai_lekpaet", 0x0e58},
+	0x00000df9: {"Thai_lekkao", 0x0e59},
+	0x00000ea1: {"Hangul_Kiyeog", 0x3131},
+	0x00000ea2: {"Hangul_SsangKiyeog", 0x3132},
+	0x00000ea3: {"Hangul_KiyeogSios", 0x3133},
+	0x00000ea4: {"Hangul_Nieun", 0x3134},
+	0x00000ea5: {"Hangul_NieunJieuj", 0x3135},
+	0x00000ea6: {"Hangul_NieunHieuh", 0x3136},
+	0x00000ea7: {"Hangul_Dikeud", 0x3137},
+	0x00000ea8: {"Hangul_SsangDikeud", 0x3138},
+	0x00000ea9: {"Hangul_Rieul", 0x3139},
+	0x00000eaa: {"Hangul_RieulKiyeog", 0x313a},
+	0x00000eab: {"Hangul_RieulMieum", 0x313b},
+	0x00000eac: {"Hangul_RieulPieub", 0x313c},
+	0x00000ead: {"Hangul_RieulSios", 0x313d},
+	0x00000eae: {"Hangul_RieulTieut", 0x313e},
+	0x00000eaf: {"Hangul_RieulPhieuf", 0x313f},
+	0x00000eb0: {"Hangul_RieulHieuh", 0x3140},
+	0x00000eb1: {"Hangul_Mieum", 0x3141},
+	0x00000eb2: {"Hangul_Pieub", 0x3142},
+	0x00000eb3: {"Hangul_SsangPieub", 0x3143},
+	0x00000eb4: {"Hangul_PieubSios", 0x3144},
+	0x00000eb5: {"Hangul_Sios", 0x3145},
+	0x00000eb6: {"Hangul_SsangSios", 0x3146},
+	0x00000eb7: {"Hangul_Ieung", 0x3147},
+	0x00000eb8: {"Hangul_Jieuj", 0x3148},
+	0x00000eb9: {"Hangul_SsangJieuj", 0x3149},
+	0x00000eba: {"Hangul_Cieuc", 0x314a},
+	0x00000ebb: {"Hangul_Khieuq", 0x314b},
+	0x00000ebc: {"Hangul_Tieut", 0x314c},
+	0x00000ebd: {"Hangul_Phieuf", 0x314d},
+	0x00000ebe: {"Hangul_Hieuh", 0x314e},
+	0x00000ebf: {"Hangul_A", 0x314f},
+	0x00000ec0: {"Hangul_AE", 0x3150},
+	0x00000ec1: {"Hangul_YA", 0x3151},
+	0x00000ec2: {"Hangul_YAE", 0x3152},
+	0x00000ec3: {"Hangul_EO", 0x3153},
+	0x00000ec4: {"Hangul_E", 0x3154},
+	0x00000ec5: {"Hangul_YEO", 0x3155},
+	0x00000ec6: {"Hangul_YE", 0x3156},
+	0x00000ec7: {"Hangul_O", 0x3157},
+	0x00000ec8: {"Hangul_WA", 0x3158},
+	0x00000ec9: {"Hangul_WAE", 0x3159},
+	0x00000eca: {"Hangul_OE", 0x315a},
+	0x00000ecb: {"Hangul_YO", 0x315b},
+	0x00000ecc: {"Hangul_U", 0x315c},
+	0x00000ecd: {"Hangul_WEO", 0x315d},
+	0x00000ece: {"Hangul_WE", 0x315e},
+	0x00000ecf: {"Hangul_WI", 0x315f},
+	0x00000ed0: {"Hangul_YU", 0x3160},
+	0x00000ed1: {"Hangul_EU", 0x3161},
+	0x00000ed2: {"Hangul_YI", 0x3162},
+	0x00000ed3: {"Hangul_I", 0x3163},
+	0x00000ed4: {"Hangul_J_Kiyeog", 0x11a8},
+	0x00000ed5: {"Hangul_J_SsangKiyeog", 0x11a9},
+	0x00000ed6: {"Hangul_J_KiyeogSios", 0x11aa},
+	0x00000ed7: {"Hangul_J_Nieun", 0x11ab},
+	0x00000ed8: {"Hangul_J_NieunJieuj", 0x11ac},
+	0x00000ed9: {"Hangul_J_NieunHieuh", 0x11ad},
+	0x00000eda: {"Hangul_J_Dikeud", 0x11ae},
+	0x00000edb: {"Hangul_J_Rieul", 0x11af},
+	0x00000edc: {"Hangul_J_RieulKiyeog", 0x11b0},
+	0x00000edd: {"Hangul_J_RieulMieum", 0x11b1},
+	0x00000ede: {"Hangul_J_RieulPieub", 0x11b2},
+	0x00000edf: {"Hangul_J_RieulSios", 0x11b3},
+	0x00000ee0: {"Hangul_J_RieulTieut", 0x11b4},
+	0x00000ee1: {"Hangul_J_RieulPhieuf", 0x11b5},
+	0x00000ee2: {"Hangul_J_RieulHieuh", 0x11b6},
+	0x00000ee3: {"Hangul_J_Mieum", 0x11b7},
+	0x00000ee4: {"Hangul_J_Pieub", 0x11b8},
+	0x00000ee5: {"Hangul_J_PieubSios", 0x11b9},
+	0x00000ee6: {"Hangul_J_Sios", 0x11ba},
+	0x00000ee7: {"Hangul_J_SsangSios", 0x11bb},
+	0x00000ee8: {"Hangul_J_Ieung", 0x11bc},
+	0x00000ee9: {"Hangul_J_Jieuj", 0x11bd},
+	0x00000eea: {"Hangul_J_Cieuc", 0x11be},
+	0x00000eeb: {"Hangul_J_Khieuq", 0x11bf},
+	0x00000eec: {"Hangul_J_Tieut", 0x11c0},
+	0x00000eed: {"Hangul_J_Phieuf", 0x11c1},
+	0x00000eee: {"Hangul_J_Hieuh", 0x11c2},
+	0x00000eef: {"Hangul_RieulYeorinHieuh", 0x316d},
+	0x00000ef0: {"Hangul_SunkyeongeumMieum", 0x3171},
+	0x00000ef1: {"Hangul_SunkyeongeumPieub", 0x3178},
+	0x00000ef2: {"Hangul_PanSios", 0x317f},
+	0x00000ef3: {"Hangul_KkogjiDalrinIeung", 0x3181},
+	0x00000ef4: {"Hangul_SunkyeongeumPhieuf", 0x3184},
+	0x00000ef5: {"Hangul_YeorinHieuh", 0x3186},
+	0x00000ef6: {"Hangul_AraeA", 0x318d},
+	0x00000ef7: {"Hangul_AraeAE", 0x318e},
+	0x00000ef8: {"Hangul_J_PanSios", 0x11eb},
+	0x00000ef9: {"Hangul_J_KkogjiDalrinIeung", 0x11f0},
+	0x00000efa: {"Hangul_J_YeorinHieuh", 0x11f9},
+	0x00000eff: {"Korean_Won", 0x20a9},
+	0x000013bc: {"OE", 0x0152},
+	0x000013bd: {"oe", 0x0153},
+	0x000013be: {"Ydiaeresis", 0x0178},
+	0x000020ac: {"EuroSign", 0x20ac},
+	0x0000fd01: {"3270_Duplicate", 0x0000},
+	0x0000fd02: {"3270_FieldMark", 0x0000},
+	0x0000fd03: {"3270_Right2", 0x0000},
+	0x0000fd04: {"3270_Left2", 0x0000},
+	0x0000fd05: {"3270_BackTab", 0x0000},
+	0x0000fd06: {"3270_EraseEOF", 0x0000},
+	0x0000fd07: {"3270_EraseInput", 0x0000},
+	0x0000fd08: {"3270_Reset", 0x0000},
+	0x0000fd09: {"3270_Quit", 0x0000},
+	0x0000fd0a: {"3270_PA1", 0x0000},
+	0x0000fd0b: {"3270_PA2", 0x0000},
+	0x0000fd0c: {"3270_PA3", 0x0000},
+	0x0000fd0d: {"3270_Test", 0x0000},
+	0x0000fd0e: {"3270_Attn", 0x0000},
+	0x0000fd0f: {"3270_CursorBlink", 0x0000},
+	0x0000fd10: {"3270_AltCursor", 0x0000},
+	0x0000fd11: {"3270_KeyClick", 0x0000},
+	0x0000fd12: {"3270_Jump", 0x0000},
+	0x0000fd13: {"3270_Ident", 0x0000},
+	0x0000fd14: {"3270_Rule", 0x0000},
+	0x0000fd15: {"3270_Copy", 0x0000},
+	0x0000fd16: {"3270_Play", 0x0000},
+	0x0000fd17: {"3270_Setup", 0x0000},
+	0x0000fd18: {"3270_Record", 0x0000},
+	0x0000fd19: {"3270_ChangeScreen", 0x0000},
+	0x0000fd1a: {"3270_DeleteWord", 0x0000},
+	0x0000fd1b: {"3270_ExSelect", 0x0000},
+	0x0000fd1c: {"3270_CursorSelect", 0x0000},
+	0x0000fd1d: {"3270_PrintScreen", 0x0000},
+	0x0000fd1e: {"3270_Enter", 0x0000},
+	0x0000fe01: {"ISO_Lock", 0x0000},
+	0x0000fe02: {"ISO_Level2_Latch", 0x0000},
+	0x0000fe03: {"ISO_Level3_Shift", 0x0000},
+	0x0000fe04: {"ISO_Level3_Latch", 0x0000},
+	0x0000fe05: {"ISO_Level3_Lock", 0x0000},
+	0x0000fe06: {"ISO_Group_Latch", 0x0000},
+	0x0000fe07: {"ISO_Group_Lock", 0x0000},
+	0x0000fe08: {"ISO_Next_Group", 0x0000},
+	0x0000fe09: {"ISO_Next_Group_Lock", 0x0000},
+	0x0000fe0a: {"ISO_Prev_Group", 0x0000},
+	0x0000fe0b: {"ISO_Prev_Group_Lock", 0x0000},
+	0x0000fe0c: {"ISO_First_Group", 0x0000},
+	0x0000fe0d: {"ISO_First_Group_Lock", 0x0000},
+	0x0000fe0e: {"ISO_Last_Group", 0x0000},
+	0x0000fe0f: {"ISO_Last_Group_Lock", 0x0000},
+	0x0000fe11: {"ISO_Level5_Shift", 0x0000},
+	0x0000fe12: {"ISO_Level5_Latch", 0x0000},
+	0x0000fe13: {"ISO_Level5_Lock", 0x0000},
+	0x0000fe20: {"ISO_Left_Tab", 0x0000},
+	0x0000fe21: {"ISO_Move_Line_Up", 0x0000},
+	0x0000fe22: {"ISO_Move_Line_Down", 0x0000},
+	0x0000fe23: {"ISO_Partial_Line_Up", 0x0000},
+	0x0000fe24: {"ISO_Partial_Line_Down", 0x0000},
+	0x0000fe25: {"ISO_Partial_Space_Left", 0x0000},
+	0x0000fe26: {"ISO_Partial_Space_Right", 0x0000},
+	0x0000fe27: {"ISO_Set_Margin_Left", 0x0000},
+	0x0000fe28: {"ISO_Set_Margin_Right", 0x0000},
+	0x0000fe29: {"ISO_Release_Margin_Left", 0x0000},
+	0x0000fe2a: {"ISO_Release_Margin_Right", 0x0000},
+	0x0000fe2b: {"ISO_Release_Both_Margins", 0x0000},
+	0x0000fe2c: {"ISO_Fast_Cursor_Left", 0x0000},
+	0x0000fe2d: {"ISO_Fast_Cursor_Right", 0x0000},
+	0x0000fe2e: {"ISO_Fast_Cursor_Up", 0x0000},
+	0x0000fe2f: {"ISO_Fast_Cursor_Down", 0x0000},
+	0x0000fe30: {"ISO_Continuous_Underline", 0x0000},
+	0x0000fe31: {"ISO_Discontinuous_Underline", 0x0000},
+	0x0000fe32: {"ISO_Emphasize", 0x0000},
+	0x0000fe33: {"ISO_Center_Object", 0x0000},
+	0x0000fe34: {"ISO_Enter", 0x0000},
+	0x0000fe50: {"dead_grave", 0x0000},
+	0x0000fe51: {"dead_acute", 0x0000},
+	0x0000fe52: {"dead_circumflex", 0x0000},
+	0x0000fe53: {"dead_tilde", 0x0000},
+	0x0000fe54: {"dead_macron", 0x0000},
+	0x0000fe55: {"dead_breve", 0x0000},
+	0x0000fe56: {"dead_abovedot", 0x0000},
+	0x0000fe57: {"dead_diaeresis", 0x0000},
+	0x0000fe58: {"dead_abovering", 0x0000},
+	0x0000fe59: {"dead_doubleacute", 0x0000},
+	0x0000fe5a: {"dead_caron", 0x0000},
+	0x0000fe5b: {"dead_cedilla", 0x0000},
+	0x0000fe5c: {"dead_ogonek", 0x0000},
+	0x0000fe5d: {"dead_iota", 0x0000},
+	0x0000fe5e: {"dead_voiced_sound", 0x0000},
+	0x0000fe5f: {"dead_semivoiced_sound", 0x0000},
+	0x0000fe60: {"dead_belowdot", 0x0000},
+	0x0000fe61: {"dead_hook", 0x0000},
+	0x0000fe62: {"dead_horn", 0x0000},
+	0x0000fe63: {"dead_stroke", 0x0000},
+	0x0000fe64: {"dead_abovecomma", 0x0000},
+	0x0000fe65: {"dead_abovereversedcomma", 0x0000},
+	0x0000fe66: {"dead_doublegrave", 0x0000},
+	0x0000fe67: {"dead_belowring", 0x0000},
+	0x0000fe68: {"dead_belowmacron", 0x0000},
+	0x0000fe69: {"dead_belowcircumflex", 0x0000},
+	0x0000fe6a: {"dead_belowtilde", 0x0000},
+	0x0000fe6b: {"dead_belowbreve", 0x0000},
+	0x0000fe6c: {"dead_belowdiaeresis", 0x0000},
+	0x0000fe6d: {"dead_invertedbreve", 0x0000},
+	0x0000fe6e: {"dead_belowcomma", 0x0000},
+	0x0000fe6f: {"dead_currency", 0x0000},
+	0x0000fe70: {"AccessX_Enable", 0x0000},
+	0x0000fe71: {"AccessX_Feedback_Enable", 0x0000},
+	0x0000fe72: {"RepeatKeys_Enable", 0x0000},
+	0x0000fe73: {"SlowKeys_Enable", 0x0000},
+	0x0000fe74: {"BounceKeys_Enable", 0x0000},
+	0x0000fe75: {"StickyKeys_Enable", 0x0000},
+	0x0000fe76: {"MouseKeys_Enable", 0x0000},
+	0x0000fe77: {"MouseKeys_Accel_Enable", 0x0000},
+	0x0000fe78: {"Overlay1_Enable", 0x0000},
+	0x0000fe79: {"Overlay2_Enable", 0x0000},
+	0x0000fe7a: {"AudibleBell_Enable", 0x0000},
+	0x0000fe80: {"dead_a", 0x0000},
+	0x0000fe81: {"dead_A", 0x0000},
+	0x0000fe82: {"dead_e", 0x0000},
+	0x0000fe83: {"dead_E", 0x0000},
+	0x0000fe84: {"dead_i", 0x0000},
+	0x0000fe85: {"dead_I", 0x0000},
+	0x0000fe86: {"dead_o", 0x0000},
+	0x0000fe87: {"dead_O", 0x0000},
+	0x0000fe88: {"dead_u", 0x0000},
+	0x0000fe89: {"dead_U", 0x0000},
+	0x0000fe8a: {"dead_small_schwa", 0x0000},
+	0x0000fe8b: {"dead_capital_schwa", 0x0000},
+	0x0000fe8c: {"dead_greek", 0x0000},
+	0x0000fe90: {"dead_lowline", 0x0000},
+	0x0000fe91: {"dead_aboveverticalline", 0x0000},
+	0x0000fe92: {"dead_belowverticalline", 0x0000},
+	0x0000fe93: {"dead_longsolidusoverlay", 0x0000},
+	0x0000fea0: {"ch", 0x0000},
+	0x0000fea1: {"Ch", 0x0000},
+	0x0000fea2: {"CH", 0x0000},
+	0x0000fea3: {"c_h", 0x0000},
+	0x0000fea4: {"C_h", 0x0000},
+	0x0000fea5: {"C_H", 0x0000},
+	0x0000fed0: {"First_Virtual_Screen", 0x0000},
+	0x0000fed1: {"Prev_Virtual_Screen", 0x0000},
+	0x0000fed2: {"Next_Virtual_Screen", 0x0000},
+	0x0000fed4: {"Last_Virtual_Screen", 0x0000},
+	0x0000fed5: {"Terminate_Server", 0x0000},
+	0x0000fee0: {"Pointer_Left", 0x0000},
+	0x0000fee1: {"Pointer_Right", 0x0000},
+	0x0000fee2: {"Pointer_Up", 0x0000},
+	0x0000fee3: {"Pointer_Down", 0x0000},
+	0x0000fee4: {"Pointer_UpLeft", 0x0000},
+	0x0000fee5: {"Pointer_UpRight", 0x0000},
+	0x0000fee6: {"Pointer_DownLeft", 0x0000},
+	0x0000fee7: {"Pointer_DownRight", 0x0000},
+	0x0000fee8: {"Pointer_Button_Dflt", 0x0000},
+	0x0000fee9: {"Pointer_Button1", 0x0000},
+	0x0000feea: {"Pointer_Button2", 0x0000},
+	0x0000feeb: {"Pointer_Button3", 0x0000},
+	0x0000feec: {"Pointer_Button4", 0x0000},
+	0x0000feed: {"Pointer_Button5", 0x0000},
+	0x0000feee: {"Pointer_DblClick_Dflt", 0x0000},
+	0x0000feef: {"Pointer_DblClick1", 0x0000},
+	0x0000fef0: {"Pointer_DblClick2", 0x0000},
+	0x0000fef1: {"Pointer_DblClick3", 0x0000},
+	0x0000fef2: {"Pointer_DblClick4", 0x0000},
+	0x0000fef3: {"Pointer_DblClick5", 0x0000},
+	0x0000fef4: {"Pointer_Drag_Dflt", 0x0000},
+	0x0000fef5: {"Pointer_Drag1", 0x0000},
+	0x0000fef6: {"Pointer_Drag2", 0x0000},
+	0x0000fef7: {"Pointer_Drag3", 0x0000},
+	0x0000fef8: {"Pointer_Drag4", 0x0000},
+	0x0000fef9: {"Pointer_EnableKeys", 0x0000},
+	0x0000fefa: {"Pointer_Accelerate", 0x0000},
+	0x0000fefb: {"Pointer_DfltBtnNext", 0x0000},
+	0x0000fefc: {"Pointer_DfltBtnPrev", 0x0000},
+	0x0000fefd: {"Pointer_Drag5", 0x0000},
+	0x0000ff08: {"BackSpace", 0x0000},
+	0x0000ff09: {"Tab", 0x0000},
+	0x0000ff0a: {"Linefeed", 0x0000},
+	0x0000ff0b: {"Clear", 0x0000},
+	0x0000ff0d: {"Return", 0x0000},
+	0x0000ff13: {"Pause", 0x0000},
+	0x0000ff14: {"Scroll_Lock", 0x0000},
+	0x0000ff15: {"Sys_Req", 0x0000},
+	0x0000ff1b: {"Escape", 0x0000},
+	0x0000ff20: {"Multi_key", 0x0000},
+	0x0000ff21: {"Kanji", 0x0000},
+	0x0000ff22: {"Muhenkan", 0x0000},
+	0x0000ff23: {"Henkan_Mode", 0x0000},
+	0x0000ff24: {"Romaji", 0x0000},
+	0x0000ff25: {"Hiragana", 0x0000},
+	0x0000ff26: {"Katakana", 0x0000},
+	0x0000ff27: {"Hiragana_Katakana", 0x0000},
+	0x0000ff28: {"Zenkaku", 0x0000},
+	0x0000ff29: {"Hankaku", 0x0000},
+	0x0000ff2a: {"Zenkaku_Hankaku", 0x0000},
+	0x0000ff2b: {"Touroku", 0x0000},
+	0x0000ff2c: {"Massyo", 0x0000},
+	0x0000ff2d: {"Kana_Lock", 0x0000},
+	0x0000ff2e: {"Kana_Shift", 0x0000},
+	0x0000ff2f: {"Eisu_Shift", 0x0000},
+	0x0000ff30: {"Eisu_toggle", 0x0000},
+	0x0000ff31: {"Hangul", 0x0000},
+	0x0000ff32: {"Hangul_Start", 0x0000},
+	0x0000ff33: {"Hangul_End", 0x0000},
+	0x0000ff34: {"Hangul_Hanja", 0x0000},
+	0x0000ff35: {"Hangul_Jamo", 0x0000},
+	0x0000ff36: {"Hangul_Romaja", 0x0000},
+	0x0000ff37: {"Codeinput", 0x0000},
+	0x0000ff38: {"Hangul_Jeonja", 0x0000},
+	0x0000ff39: {"Hangul_Banja", 0x0000},
+	0x0000ff3a: {"Hangul_PreHanja", 0x0000},
+	0x0000ff3b: {"Hangul_PostHanja", 0x0000},
+	0x0000ff3c: {"SingleCandidate", 0x0000},
+	0x0000ff3d: {"MultipleCandidate", 0x0000},
+	0x0000ff3e: {"PreviousCandidate", 0x0000},
+	0x0000ff3f: {"Hangul_Special", 0x0000},
+	0x0000ff50: {"Home", 0x0000},
+	0x0000ff51: {"Left", 0x0000},
+	0x0000ff52: {"Up", 0x0000},
+	0x0000ff53: {"Right", 0x0000},
+	0x0000ff54: {"Down", 0x0000},
+	0x0000ff55: {"Prior", 0x0000},
+	0x0000ff56: {"Next", 0x0000},
+	0x0000ff57: {"End", 0x0000},
+	0x0000ff58: {"Begin", 0x0000},
+	0x0000ff60: {"Select", 0x0000},
+	0x0000ff61: {"Print", 0x0000},
+	0x0000ff62: {"Execute", 0x0000},
+	0x0000ff63: {"Insert", 0x0000},
+	0x0000ff65: {"Undo", 0x0000},
+	0x0000ff66: {"Redo", 0x0000},
+	0x0000ff67: {"Menu", 0x0000},
+	0x0000ff68: {"Find", 0x0000},
+	0x0000ff69: {"Cancel", 0x0000},
+	0x0000ff6a: {"Help", 0x0000},
+	0x0000ff6b: {"Break", 0x0000},
+	0x0000ff7e: {"Mode_switch", 0x0000},
+	0x0000ff7f: {"Num_Lock", 0x0000},
+	0x0000ff80: {"KP_Space", 0x0000},
+	0x0000ff89: {"KP_Tab", 0x0000},
+	0x0000ff8d: {"KP_Enter", 0x0000},
+	0x0000ff91: {"KP_F1", 0x0000},
+	0x0000ff92: {"KP_F2", 0x0000},
+	0x0000ff93: {"KP_F3", 0x0000},
+	0x0000ff94: {"KP_F4", 0x0000},
+	0x0000ff95: {"KP_Home", 0x0000},
+	0x0000ff96: {"KP_Left", 0x0000},
+	0x0000ff97: {"KP_Up", 0x0000},
+	0x0000ff98: {"KP_Right", 0x0000},
+	0x0000ff99: {"KP_Down", 0x0000},
+	0x0000ff9a: {"KP_Prior", 0x0000},
+	0x0000ff9b: {"KP_Next", 0x0000},
+	0x0000ff9c: {"KP_End", 0x0000},
+	0x0000ff9d: {"KP_Begin", 0x0000},
+	0x0000ff9e: {"KP_Insert", 0x0000},
+	0x0000ff9f: {"KP_Delete", 0x0000},
+	0x0000ffaa: {"KP_Multiply", 0x0000},
+	0x0000ffab: {"KP_Add", 0x0000},
+	0x0000ffac: {"KP_Separator", 0x0000},
+	0x0000ffad: {"KP_Subtract", 0x0000},
+	0x0000ffae: {"KP_Decimal", 0x0000},
+	0x0000ffaf: {"KP_Divide", 0x0000},
+	0x0000ffb0: {"KP_0", 0x0000},
+	0x0000ffb1: {"KP_1", 0x0000},
+	0x0000ffb2: {"KP_2", 0x0000},
+	0x0000ffb3: {"KP_3", 0x0000},
+	0x0000ffb4: {"KP_4", 0x0000},
+	0x0000ffb5: {"KP_5", 0x0000},
+	0x0000ffb6: {"KP_6", 0x0000},
+	0x0000ffb7: {"KP_7", 0x0000},
+	0x0000ffb8: {"KP_8", 0x0000},
+	0x0000ffb9: {"KP_9", 0x0000},
+	0x0000ffbd: {"KP_Equal", 0x0000},
+	0x0000ffbe: {"F1", 0x0000},
+	0x0000ffbf: {"F2", 0x0000},
+	0x0000ffc0: {"F3", 0x0000},
+	0x0000ffc1: {"F4", 0x0000},
+	0x0000ffc2: {"F5", 0x0000},
+	0x0000ffc3: {"F6", 0x0000},
+	0x0000ffc4: {"F7", 0x0000},
+	0x0000ffc5: {"F8", 0x0000},
+	0x0000ffc6: {"F9", 0x0000},
+	0x0000ffc7: {"F10", 0x0000},
+	0x0000ffc8: {"F11", 0x0000},
+	0x0000ffc9: {"F12", 0x0000},
+	0x0000ffca: {"F13", 0x0000},
+	0x0000ffcb: {"F14", 0x0000},
+	0x0000ffcc: {"F15", 0x0000},
+	0x0000ffcd: {"F16", 0x0000},
+	0x0000ffce: {"F17", 0x0000},
+	0x0000ffcf: {"F18", 0x0000},
+	0x0000ffd0: {"F19", 0x0000},
+	0x0000ffd1: {"F20", 0x0000},
+	0x0000ffd2: {"F21", 0x0000},
+	0x0000ffd3: {"F22", 0x0000},
+	0x0000ffd4: {"F23", 0x0000},
+	0x0000ffd5: {"F24", 0x0000},
+	0x0000ffd6: {"F25", 0x0000},
+	0x0000ffd7: {"F26", 0x0000},
+	0x0000ffd8: {"F27", 0x0000},
+	0x0000ffd9: {"F28", 0x0000},
+	0x0000ffda: {"F29", 0x0000},
+	0x0000ffdb: {"F30", 0x0000},
+	0x0000ffdc: {"F31", 0x0000},
+	0x0000ffdd: {"F32", 0x0000},
+	0x0000ffde: {"F33", 0x0000},
+	0x0000ffdf: {"F34", 0x0000},
+	0x0000ffe0: {"F35", 0x0000},
+	0x0000ffe1: {"Shift_L", 0x0000},
+	0x0000ffe2: {"Shift_R", 0x0000},
+	0x0000ffe3: {"Control_L", 0x0000},
+	0x0000ffe4: {"Control_R", 0x0000},
+	0x0000ffe5: {"Caps_Lock", 0x0000},
+	0x0000ffe6: {"Shift_Lock", 0x0000},
+	0x0000ffe7: {"Meta_L", 0x0000},
+	0x0000ffe8: {"Meta_R", 0x0000},
+	0x0000ffe9: {"Alt_L", 0x0000},
+	0x0000ffea: {"Alt_R", 0x0000},
+	0x0000ffeb: {"Super_L", 0x0000},
+	0x0000ffec: {"Super_R", 0x0000},
+	0x0000ffed: {"Hyper_L", 0x0000},
+	0x0000ffee: {"Hyper_R", 0x0000},
+	0x0000fff1: {"braille_dot_1", 0x0000},
+	0x0000fff2: {"braille_dot_2", 0x0000},
+	0x0000fff3: {"braille_dot_3", 0x0000},
+	0x0000fff4: {"braille_dot_4", 0x0000},
+	0x0000fff5: {"braille_dot_5", 0x0000},
+	0x0000fff6: {"braille_dot_6", 0x0000},
+	0x0000fff7: {"braille_dot_7", 0x0000},
+	0x0000fff8: {"braille_dot_8", 0x0000},
+	0x0000fff9: {"braille_dot_9", 0x0000},
+	0x0000fffa: {"braille_dot_10", 0x0000},
+	0x0000ffff: {"Delete", 0x0000},
+	0x00ffffff: {"VoidSymbol", 0x0000},
+	0x0100012c: {"Ibreve", 0x012c},
+	0x0100012d: {"ibreve", 0x012d},
+	0x01000174: {"Wcircumflex", 0x0174},
+	0x01000175: {"wcircumflex", 0x0175},
+	0x01000176: {"Ycircumflex", 0x0176},
+	0x01000177: {"ycircumflex", 0x0177},
+	0x0100018f: {"SCHWA", 0x018f},
+	0x0100019f: {"Obarred", 0x019f},
+	0x010001a0: {"Ohorn", 0x01a0},
+	0x010001a1: {"ohorn", 0x01a1},
+	0x010001af: {"Uhorn", 0x01af},
+	0x010001b0: {"uhorn", 0x01b0},
+	0x010001b5: {"Zstroke", 0x01b5},
+	0x010001b6: {"zstroke", 0x01b6},
+	0x010001b7: {"EZH", 0x01b7},
+	0x010001d1: {"Ocaron", 0x01d1},
+	0x010001d2: {"ocaron", 0x01d2},
+	0x010001e6: {"Gcaron", 0x01e6},
+	0x010001e7: {"gcaron", 0x01e7},
+	0x01000259: {"schwa", 0x0259},
+	0x01000275: {"obarred", 0x0275},
+	0x01000292: {"ezh", 0x0292},
+	0x01000300: {"combining_grave", 0x0300},
+	0x01000301: {"combining_acute", 0x0301},
+	0x01000303: {"combining_tilde", 0x0303},
+	0x01000309: {"combining_hook", 0x0309},
+	0x01000323: {"combining_belowdot", 0x0323},
+	0x01000492: {"Cyrillic_GHE_bar", 0x0492},
+	0x01000493: {"Cyrillic_ghe_bar", 0x0493},
+	0x01000496: {"Cyrillic_ZHE_descender", 0x0496},
+	0x01000497: {"Cyrillic_zhe_descender", 0x0497},
+	0x0100049a: {"Cyrillic_KA_descender", 0x049a},
+	0x0100049b: {"Cyrillic_ka_descender", 0x049b},
+	0x0100049c: {"Cyrillic_KA_vertstroke", 0x049c},
+	0x0100049d: {"Cyrillic_ka_vertstroke", 0x049d},
+	0x010004a2: {"Cyrillic_EN_descender", 0x04a2},
+	0x010004a3: {"Cyrillic_en_descender", 0x04a3},
+	0x010004ae: {"Cyrillic_U_straight", 0x04ae},
+	0x010004af: {"Cyrillic_u_straight", 0x04af},
+	0x010004b0: {"Cyrillic_U_straight_bar", 0x04b0},
+	0x010004b1: {"Cyrillic_u_straight_bar", 0x04b1},
+	0x010004b2: {"Cyrillic_HA_descender", 0x04b2},
+	0x010004b3: {"Cyrillic_ha_descender", 0x04b3},
+	0x010004b6: {"Cyrillic_CHE_descender", 0x04b6},
+	0x010004b7: {"Cyrillic_che_descender", 0x04b7},
+	0x010004b8: {"Cyrillic_CHE_vertstroke", 0x04b8},
+	0x010004b9: {"Cyrillic_che_vertstroke", 0x04b9},
+	0x010004ba: {"Cyrillic_SHHA", 0x04ba},
+	0x010004bb: {"Cyrillic_shha", 0x04bb},
+	0x010004d8: {"Cyrillic_SCHWA", 0x04d8},
+	0x010004d9: {"Cyrillic_schwa", 0x04d9},
+	0x010004e2: {"Cyrillic_I_macron", 0x04e2},
+	0x010004e3: {"Cyrillic_i_macron", 0x04e3},
+	0x010004e8: {"Cyrillic_O_bar", 0x04e8},
+	0x010004e9: {"Cyrillic_o_bar", 0x04e9},
+	0x010004ee: {"Cyrillic_U_macron", 0x04ee},
+	0x010004ef: {"Cyrillic_u_macron", 0x04ef},
+	0x01000531: {"Armenian_AYB", 0x0531},
+	0x01000532: {"Armenian_BEN", 0x0532},
+	0x01000533: {"Armenian_GIM", 0x0533},
+	0x01000534: {"Armenian_DA", 0x0534},
+	0x01000535: {"Armenian_YECH", 0x0535},
+	0x01000536: {"Armenian_ZA", 0x0536},
+	0x01000537: {"Armenian_E", 0x0537},
+	0x01000538: {"Armenian_AT", 0x0538},
+	0x01000539: {"Armenian_TO", 0x0539},
+	0x0100053a: {"Armenian_ZHE", 0x053a},
+	0x0100053b: {"Armenian_INI", 0x053b},
+	0x0100053c: {"Armenian_LYUN", 0x053c},
+	0x0100053d: {"Armenian_KHE", 0x053d},
+	0x0100053e: {"Armenian_TSA", 0x053e},
+	0x0100053f: {"Armenian_KEN", 0x053f},
+	0x01000540: {"Armenian_HO", 0x0540},
+	0x01000541: {"Armenian_DZA", 0x0541},
+	0x01000542: {"Armenian_GHAT", 0x0542},
+	0x01000543: {"Armenian_TCHE", 0x0543},
+	0x01000544: {"Armenian_MEN", 0x0544},
+	0x01000545: {"Armenian_HI", 0x0545},
+	0x01000546: {"Armenian_NU", 0x0546},
+	0x01000547: {"Armenian_SHA", 0x0547},
+	0x01000548: {"Armenian_VO", 0x0548},
+	0x01000549: {"Armenian_CHA", 0x0549},
+	0x0100054a: {"Armenian_PE", 0x054a},
+	0x0100054b: {"Armenian_JE", 0x054b},
+	0x0100054c: {"Armenian_RA", 0x054c},
+	0x0100054d: {"Armenian_SE", 0x054d},
+	0x0100054e: {"Armenian_VEV", 0x054e},
+	0x0100054f: {"Armenian_TYUN", 0x054f},
+	0x01000550: {"Armenian_RE", 0x0550},
+	0x01000551: {"Armenian_TSO", 0x0551},
+	0x01000552: {"Armenian_VYUN", 0x0552},
+	0x01000553: {"Armenian_PYUR", 0x0553},
+	0x01000554: {"Armenian_KE", 0x0554},
+	0x01000555: {"Armenian_O", 0x0555},
+	0x01000556: {"Armenian_FE", 0x0556},
+	0x0100055a: {"Armenian_apostrophe", 0x055a},
+	0x0100055b: {"Armenian_accent", 0x055b},
+	0x0100055c: {"Armenian_exclam", 0x055c},
+	0x0100055d: {"Armenian_separation_mark", 0x055d},
+	0x0100055e: {"Armenian_question", 0x055e},
+	0x01000561: {"Armenian_ayb", 0x0561},
+	0x01000562: {"Armenian_ben", 0x0562},
+	0x01000563: {"Armenian_gim", 0x0563},
+	0x01000564: {"Armenian_da", 0x0564},
+	0x01000565: {"Armenian_yech", 0x0565},
+	0x01000566: {"Armenian_za", 0x0566},
+	0x01000567: {"Armenian_e", 0x0567},
+	0x01000568: {"Armenian_at", 0x0568},
+	0x01000569: {"Armenian_to", 0x0569},
+	0x0100056a: {"Armenian_zhe", 0x056a},
+	0x0100056b: {"Armenian_ini", 0x056b},
+	0x0100056c: {"Armenian_lyun", 0x056c},
+	0x0100056d: {"Armenian_khe", 0x056d},
+	0x0100056e: {"Armenian_tsa", 0x056e},
+	0x0100056f: {"Armenian_ken", 0x056f},
+	0x01000570: {"Armenian_ho", 0x0570},
+	0x01000571: {"Armenian_dza", 0x0571},
+	0x01000572: {"Armenian_ghat", 0x0572},
+	0x01000573: {"Armenian_tche", 0x0573},
+	0x01000574: {"Armenian_men", 0x0574},
+	0x01000575: {"Armenian_hi", 0x0575},
+	0x01000576: {"Armenian_nu", 0x0576},
+	0x01000577: {"Armenian_sha", 0x0577},
+	0x01000578: {"Armenian_vo", 0x0578},
+	0x01000579: {"Armenian_cha", 0x0579},
+	0x0100057a: {"Armenian_pe", 0x057a},
+	0x0100057b: {"Armenian_je", 0x057b},
+	0x0100057c: {"Armenian_ra", 0x057c},
+	0x0100057d: {"Armenian_se", 0x057d},
+	0x0100057e: {"Armenian_vev", 0x057e},
+	0x0100057f: {"Armenian_tyun", 0x057f},
+	0x01000580: {"Armenian_re", 0x0580},
+	0x01000581: {"Armenian_tso", 0x0581},
+	0x01000582: {"Armenian_vyun", 0x0582},
+	0x01000583: {"Armenian_pyur", 0x0583},
+	0x01000584: {"Armenian_ke", 0x0584},
+	0x01000585: {"Armenian_o", 0x0585},
+	0x01000586: {"Armenian_fe", 0x0586},
+	0x01000587: {"Armenian_ligature_ew", 0x0587},
+	0x01000589: {"Armenian_full_stop", 0x0589},
+	0x0100058a: {"Armenian_hyphen", 0x058a},
+	0x01000653: {"Arabic_madda_above", 0x0653},
+	0x01000654: {"Arabic_hamza_above", 0x0654},
+	0x01000655: {"Arabic_hamza_below", 0x0655},
+	0x01000660: {"Arabic_0", 0x0660},
+	0x01000661: {"Arabic_1", 0x0661},
+	0x01000662: {"Arabic_2", 0x0662},
+	0x01000663: {"Arabic_3", 0x0663},
+	0x01000664: {"Arabic_4", 0x0664},
+	0x01000665: {"Arabic_5", 0x0665},
+	0x01000666: {"Arabic_6", 0x0666},
+	0x01000667: {"Arabic_7", 0x0667},
+	0x01000668: {"Arabic_8", 0x0668},
+	0x01000669: {"Arabic_9", 0x0669},
+	0x0100066a: {"Arabic_percent", 0x066a},
+	0x01000670: {"Arabic_superscript_alef", 0x0670},
+	0x01000679: {"Arabic_tteh", 0x0679},
+	0x0100067e: {"Arabic_peh", 0x067e},
+	0x01000686: {"Arabic_tcheh", 0x0686},
+	0x01000688: {"Arabic_ddal", 0x0688},
+	0x01000691: {"Arabic_rreh", 0x0691},
+	0x01000698: {"Arabic_jeh", 0x0698},
+	0x010006a4: {"Arabic_veh", 0x06a4},
+	0x010006a9: {"Arabic_keheh", 0x06a9},
+	0x010006af: {"Arabic_gaf", 0x06af},
+	0x010006ba: {"Arabic_noon_ghunna", 0x06ba},
+	0x010006be: {"Arabic_heh_doachashmee", 0x06be},
+	0x010006c1: {"Arabic_heh_goal", 0x06c1},
+	0x010006cc: {"Farsi_yeh", 0x06cc},
+	0x010006d2: {"Arabic_yeh_baree", 0x06d2},
+	0x010006d4: {"Arabic_fullstop", 0x06d4},
+	0x010006f0: {"Farsi_0", 0x06f0},
+	0x010006f1: {"Farsi_1", 0x06f1},
+	0x010006f2: {"Farsi_2", 0x06f2},
+	0x010006f3: {"Farsi_3", 0x06f3},
+	0x010006f4: {"Farsi_4", 0x06f4},
+	0x010006f5: {"Farsi_5", 0x06f5},
+	0x010006f6: {"Farsi_6", 0x06f6},
+	0x010006f7: {"Farsi_7", 0x06f7},
+	0x010006f8: {"Farsi_8", 0x06f8},
+	0x010006f9: {"Farsi_9", 0x06f9},
+	0x01000d82: {"Sinh_ng", 0x0d82},
+	0x01000d83: {"Sinh_h2", 0x0d83},
+	0x01000d85: {"Sinh_a", 0x0d85},
+	0x01000d86: {"Sinh_aa", 0x0d86},
+	0x01000d87: {"Sinh_ae", 0x0d87},
+	0x01000d88: {"Sinh_aee", 0x0d88},
+	0x01000d89: {"Sinh_i", 0x0d89},
+	0x01000d8a: {"Sinh_ii", 0x0d8a},
+	0x01000d8b: {"Sinh_u", 0x0d8b},
+	0x01000d8c: {"Sinh_uu", 0x0d8c},
+	0x01000d8d: {"Sinh_ri", 0x0d8d},
+	0x01000d8e: {"Sinh_rii", 0x0d8e},
+	0x01000d8f: {"Sinh_lu", 0x0d8f},
+	0x01000d90: {"Sinh_luu", 0x0d90},
+	0x01000d91: {"Sinh_e", 0x0d91},
+	0x01000d92: {"Sinh_ee", 0x0d92},
+	0x01000d93: {"Sinh_ai", 0x0d93},
+	0x01000d94: {"Sinh_o", 0x0d94},
+	0x01000d95: {"Sinh_oo", 0x0d95},
+	0x01000d96: {"Sinh_au", 0x0d96},
+	0x01000d9a: {"Sinh_ka", 0x0d9a},
+	0x01000d9b: {"Sinh_kha", 0x0d9b},
+	0x01000d9c: {"Sinh_ga", 0x0d9c},
+	0x01000d9d: {"Sinh_gha", 0x0d9d},
+	0x01000d9e: {"Sinh_ng2", 0x0d9e},
+	0x01000d9f: {"Sinh_nga", 0x0d9f},
+	0x01000da0: {"Sinh_ca", 0x0da0},
+	0x01000da1: {"Sinh_cha", 0x0da1},
+	0x01000da2: {"Sinh_ja", 0x0da2},
+	0x01000da3: {"Sinh_jha", 0x0da3},
+	0x01000da4: {"Sinh_nya", 0x0da4},
+	0x01000da5: {"Sinh_jnya", 0x0da5},
+	0x01000da6: {"Sinh_nja", 0x0da6},
+	0x01000da7: {"Sinh_tta", 0x0da7},
+	0x01000da8: {"Sinh_ttha", 0x0da8},
+	0x01000da9: {"Sinh_dda", 0x0da9},
+	0x01000daa: {"Sinh_ddha", 0x0daa},
+	0x01000dab: {"Sinh_nna", 0x0dab},
+	0x01000dac: {"Sinh_ndda", 0x0dac},
+	0x01000dad: {"Sinh_tha", 0x0dad},
+	0x01000dae: {"Sinh_thha", 0x0dae},
+	0x01000daf: {"Sinh_dha", 0x0daf},
+	0x01000db0: {"Sinh_dhha", 0x0db0},
+	0x01000db1: {"Sinh_na", 0x0db1},
+	0x01000db3: {"Sinh_ndha", 0x0db3},
+	0x01000db4: {"Sinh_pa", 0x0db4},
+	0x01000db5: {"Sinh_pha", 0x0db5},
+	0x01000db6: {"Sinh_ba", 0x0db6},
+	0x01000db7: {"Sinh_bha", 0x0db7},
+	0x01000db8: {"Sinh_ma", 0x0db8},
+	0x01000db9: {"Sinh_mba", 0x0db9},
+	0x01000dba: {"Sinh_ya", 0x0dba},
+	0x01000dbb: {"Sinh_ra", 0x0dbb},
+	0x01000dbd: {"Sinh_la", 0x0dbd},
+	0x01000dc0: {"Sinh_va", 0x0dc0},
+	0x01000dc1: {"Sinh_sha", 0x0dc1},
+	0x01000dc2: {"Sinh_ssha", 0x0dc2},
+	0x01000dc3: {"Sinh_sa", 0x0dc3},
+	0x01000dc4: {"Sinh_ha", 0x0dc4},
+	0x01000dc5: {"Sinh_lla", 0x0dc5},
+	0x01000dc6: {"Sinh_fa", 0x0dc6},
+	0x01000dca: {"Sinh_al", 0x0dca},
+	0x01000dcf: {"Sinh_aa2", 0x0dcf},
+	0x01000dd0: {"Sinh_ae2", 0x0dd0},
+	0x01000dd1: {"Sinh_aee2", 0x0dd1},
+	0x01000dd2: {"Sinh_i2", 0x0dd2},
+	0x01000dd3: {"Sinh_ii2", 0x0dd3},
+	0x01000dd4: {"Sinh_u2", 0x0dd4},
+	0x01000dd6: {"Sinh_uu2", 0x0dd6},
+	0x01000dd8: {"Sinh_ru2", 0x0dd8},
+	0x01000dd9: {"Sinh_e2", 0x0dd9},
+	0x01000dda: {"Sinh_ee2", 0x0dda},
+	0x01000ddb: {"Sinh_ai2", 0x0ddb},
+	0x01000ddc: {"Sinh_o2", 0x0ddc},
+	0x01000ddd: {"Sinh_oo2", 0x0ddd},
+	0x01000dde: {"Sinh_au2", 0x0dde},
+	0x01000ddf: {"Sinh_lu2", 0x0ddf},
+	0x01000df2: {"Sinh_ruu2", 0x0df2},
+	0x01000df3: {"Sinh_luu2", 0x0df3},
+	0x01000df4: {"Sinh_kunddaliya", 0x0df4},
+	0x010010d0: {"Georgian_an", 0x10d0},
+	0x010010d1: {"Georgian_ban", 0x10d1},
+	0x010010d2: {"Georgian_gan", 0x10d2},
+	0x010010d3: {"Georgian_don", 0x10d3},
+	0x010010d4: {"Georgian_en", 0x10d4},
+	0x010010d5: {"Georgian_vin", 0x10d5},
+	0x010010d6: {"Georgian_zen", 0x10d6},
+	0x010010d7: {"Georgian_tan", 0x10d7},
+	0x010010d8: {"Georgian_in", 0x10d8},
+	0x010010d9: {"Georgian_kan", 0x10d9},
+	0x010010da: {"Georgian_las", 0x10da},
+	0x010010db: {"Georgian_man", 0x10db},
+	0x010010dc: {"Georgian_nar", 0x10dc},
+	0x010010dd: {"Georgian_on", 0x10dd},
+	0x010010de: {"Georgian_par", 0x10de},
+	0x010010df: {"Georgian_zhar", 0x10df},
+	0x010010e0: {"Georgian_rae", 0x10e0},
+	0x010010e1: {"Georgian_san", 0x10e1},
+	0x010010e2: {"Georgian_tar", 0x10e2},
+	0x010010e3: {"Georgian_un", 0x10e3},
+	0x010010e4: {"Georgian_phar", 0x10e4},
+	0x010010e5: {"Georgian_khar", 0x10e5},
+	0x010010e6: {"Georgian_ghan", 0x10e6},
+	0x010010e7: {"Georgian_qar", 0x10e7},
+	0x010010e8: {"Georgian_shin", 0x10e8},
+	0x010010e9: {"Georgian_chin", 0x10e9},
+	0x010010ea: {"Georgian_can", 0x10ea},
+	0x010010eb: {"Georgian_jil", 0x10eb},
+	0x010010ec: {"Georgian_cil", 0x10ec},
+	0x010010ed: {"Georgian_char", 0x10ed},
+	0x010010ee: {"Georgian_xan", 0x10ee},
+	0x010010ef: {"Georgian_jhan", 0x10ef},
+	0x010010f0: {"Georgian_hae", 0x10f0},
+	0x010010f1: {"Georgian_he", 0x10f1},
+	0x010010f2: {"Georgian_hie", 0x10f2},
+	0x010010f3: {"Georgian_we", 0x10f3},
+	0x010010f4: {"Georgian_har", 0x10f4},
+	0x010010f5: {"Georgian_hoe", 0x10f5},
+	0x010010f6: {"Georgian_fi", 0x10f6},
+	0x01001e02: {"Babovedot", 0x1e02},
+	0x01001e03: {"babovedot", 0x1e03},
+	0x01001e0a: {"Dabovedot", 0x1e0a},
+	0x01001e0b: {"dabovedot", 0x1e0b},
+	0x01001e1e: {"Fabovedot", 0x1e1e},
+	0x01001e1f: {"fabovedot", 0x1e1f},
+	0x01001e36: {"Lbelowdot", 0x1e36},
+	0x01001e37: {"lbelowdot", 0x1e37},
+	0x01001e40: {"Mabovedot", 0x1e40},
+	0x01001e41: {"mabovedot", 0x1e41},
+	0x01001e56: {"Pabovedot", 0x1e56},
+	0x01001e57: {"pabovedot", 0x1e57},
+	0x01001e60: {"Sabovedot", 0x1e60},
+	0x01001e61: {"sabovedot", 0x1e61},
+	0x01001e6a: {"Tabovedot", 0x1e6a},
+	0x01001e6b: {"tabovedot", 0x1e6b},
+	0x01001e80: {"Wgrave", 0x1e80},
+	0x01001e81: {"wgrave", 0x1e81},
+	0x01001e82: {"Wacute", 0x1e82},
+	0x01001e83: {"wacute", 0x1e83},
+	0x01001e84: {"Wdiaeresis", 0x1e84},
+	0x01001e85: {"wdiaeresis", 0x1e85},
+	0x01001e8a: {"Xabovedot", 0x1e8a},
+	0x01001e8b: {"xabovedot", 0x1e8b},
+	0x01001ea0: {"Abelowdot", 0x1ea0},
+	0x01001ea1: {"abelowdot", 0x1ea1},
+	0x01001ea2: {"Ahook", 0x1ea2},
+	0x01001ea3: {"ahook", 0x1ea3},
+	0x01001ea4: {"Acircumflexacute", 0x1ea4},
+	0x01001ea5: {"acircumflexacute", 0x1ea5},
+	0x01001ea6: {"Acircumflexgrave", 0x1ea6},
+	0x01001ea7: {"acircumflexgrave", 0x1ea7},
+	0x01001ea8: {"Acircumflexhook", 0x1ea8},
+	0x01001ea9: {"acircumflexhook", 0x1ea9},
+	0x01001eaa: {"Acircumflextilde", 0x1eaa},
+	0x01001eab: {"acircumflextilde", 0x1eab},
+	0x01001eac: {"Acircumflexbelowdot", 0x1eac},
+	0x01001ead: {"acircumflexbelowdot", 0x1ead},
+	0x01001eae: {"Abreveacute", 0x1eae},
+	0x01001eaf: {"abreveacute", 0x1eaf},
+	0x01001eb0: {"Abrevegrave", 0x1eb0},
+	0x01001eb1: {"abrevegrave", 0x1eb1},
+	0x01001eb2: {"Abrevehook", 0x1eb2},
+	0x01001eb3: {"abrevehook", 0x1eb3},
+	0x01001eb4: {"Abrevetilde", 0x1eb4},
+	0x01001eb5: {"abrevetilde", 0x1eb5},
+	0x01001eb6: {"Abrevebelowdot", 0x1eb6},
+	0x01001eb7: {"abrevebelowdot", 0x1eb7},
+	0x01001eb8: {"Ebelowdot", 0x1eb8},
+	0x01001eb9: {"ebelowdot", 0x1eb9},
+	0x01001eba: {"Ehook", 0x1eba},
+	0x01001ebb: {"ehook", 0x1ebb},
+	0x01001ebc: {"Etilde", 0x1ebc},
+	0x01001ebd: {"etilde", 0x1ebd},
+	0x01001ebe: {"Ecircumflexacute", 0x1ebe},
+	0x01001ebf: {"ecircumflexacute", 0x1ebf},
+	0x01001ec0: {"Ecircumflexgrave", 0x1ec0},
+	0x01001ec1: {"ecircumflexgrave", 0x1ec1},
+	0x01001ec2: {"Ecircumflexhook", 0x1ec2},
+	0x01001ec3: {"ecircumflexhook", 0x1ec3},
+	0x01001ec4: {"Ecircumflextilde", 0x1ec4},
+	0x01001ec5: {"ecircumflextilde", 0x1ec5},
+	0x01001ec6: {"Ecircumflexbelowdot", 0x1ec6},
+	0x01001ec7: {"ecircumflexbelowdot", 0x1ec7},
+	0x01001ec8: {"Ihook", 0x1ec8},
+	0x01001ec9: {"ihook", 0x1ec9},
+	0x01001eca: {"Ibelowdot", 0x1eca},
+	0x01001ecb: {"ibelowdot", 0x1ecb},
+	0x01001ecc: {"Obelowdot", 0x1ecc},
+	0x01001ecd: {"obelowdot", 0x1ecd},
+	0x01001ece: {"Ohook", 0x1ece},
+	0x01001ecf: {"ohook", 0x1ecf},
+	0x01001ed0: {"Ocircumflexacute", 0x1ed0},
+	0x01001ed1: {"ocircumflexacute", 0x1ed1},
+	0x01001ed2: {"Ocircumflexgrave", 0x1ed2},
+	0x01001ed3: {"ocircumflexgrave", 0x1ed3},
+	0x01001ed4: {"Ocircumflexhook", 0x1ed4},
+	0x01001ed5: {"ocircumflexhook", 0x1ed5},
+	0x01001ed6: {"Ocircumflextilde", 0x1ed6},
+	0x01001ed7: {"ocircumflextilde", 0x1ed7},
+	0x01001ed8: {"Ocircumflexbelowdot", 0x1ed8},
+	0x01001ed9: {"ocircumflexbelowdot", 0x1ed9},
+	0x01001eda: {"Ohornacute", 0x1eda},
+	0x01001edb: {"ohornacute", 0x1edb},
+	0x01001edc: {"Ohorngrave", 0x1edc},
+	0x01001edd: {"ohorngrave", 0x1edd},
+	0x01001ede: {"Ohornhook", 0x1ede},
+	0x01001edf: {"ohornhook", 0x1edf},
+	0x01001ee0: {"Ohorntilde", 0x1ee0},
+	0x01001ee1: {"ohorntilde", 0x1ee1},
+	0x01001ee2: {"Ohornbelowdot", 0x1ee2},
+	0x01001ee3: {"ohornbelowdot", 0x1ee3},
+	0x01001ee4: {"Ubelowdot", 0x1ee4},
+	0x01001ee5: {"ubelowdot", 0x1ee5},
+	0x01001ee6: {"Uhook", 0x1ee6},
+	0x01001ee7: {"uhook", 0x1ee7},
+	0x01001ee8: {"Uhornacute", 0x1ee8},
+	0x01001ee9: {"uhornacute", 0x1ee9},
+	0x01001eea: {"Uhorngrave", 0x1eea},
+	0x01001eeb: {"uhorngrave", 0x1eeb},
+	0x01001eec: {"Uhornhook", 0x1eec},
+	0x01001eed: {"uhornhook", 0x1eed},
+	0x01001eee: {"Uhorntilde", 0x1eee},
+	0x01001eef: {"uhorntilde", 0x1eef},
+	0x01001ef0: {"Uhornbelowdot", 0x1ef0},
+	0x01001ef1: {"uhornbelowdot", 0x1ef1},
+	0x01001ef2: {"Ygrave", 0x1ef2},
+	0x01001ef3: {"ygrave", 0x1ef3},
+	0x01001ef4: {"Ybelowdot", 0x1ef4},
+	0x01001ef5: {"ybelowdot", 0x1ef5},
+	0x01001ef6: {"Yhook", 0x1ef6},
+	0x01001ef7: {"yhook", 0x1ef7},
+	0x01001ef8: {"Ytilde", 0x1ef8},
+	0x01001ef9: {"ytilde", 0x1ef9},
+	0x01002070: {"zerosuperior", 0x2070},
+	0x01002074: {"foursuperior", 0x2074},
+	0x01002075: {"fivesuperior", 0x2075},
+	0x01002076: {"sixsuperior", 0x2076},
+	0x01002077: {"sevensuperior", 0x2077},
+	0x01002078: {"eightsuperior", 0x2078},
+	0x01002079: {"ninesuperior", 0x2079},
+	0x01002080: {"zerosubscript", 0x2080},
+	0x01002081: {"onesubscript", 0x2081},
+	0x01002082: {"twosubscript", 0x2082},
+	0x01002083: {"threesubscript", 0x2083},
+	0x01002084: {"foursubscript", 0x2084},
+	0x01002085: {"fivesubscript", 0x2085},
+	0x01002086: {"sixsubscript", 0x2086},
+	0x01002087: {"sevensubscript", 0x2087},
+	0x01002088: {"eightsubscript", 0x2088},
+	0x01002089: {"ninesubscript", 0x2089},
+	0x010020a0: {"EcuSign", 0x20a0},
+	0x010020a1: {"ColonSign", 0x20a1},
+	0x010020a2: {"CruzeiroSign", 0x20a2},
+	0x010020a3: {"FFrancSign", 0x20a3},
+	0x010020a4: {"LiraSign", 0x20a4},
+	0x010020a5: {"MillSign", 0x20a5},
+	0x010020a6: {"NairaSign", 0x20a6},
+	0x010020a7: {"PesetaSign", 0x20a7},
+	0x010020a8: {"RupeeSign", 0x20a8},
+	0x010020a9: {"WonSign", 0x20a9},
+	0x010020aa: {"NewSheqelSign", 0x20aa},
+	0x010020ab: {"DongSign", 0x20ab},
+	0x01002202: {"partdifferential", 0x2202},
+	0x01002205: {"emptyset", 0x2205},
+	0x01002208: {"elementof", 0x2208},
+	0x01002209: {"notelementof", 0x2209},
+	0x0100220b: {"containsas", 0x220b},
+	0x0100221a: {"squareroot", 0x221a},
+	0x0100221b: {"cuberoot", 0x221b},
+	0x0100221c: {"fourthroot", 0x221c},
+	0x0100222c: {"dintegral", 0x222c},
+	0x0100222d: {"tintegral", 0x222d},
+	0x01002235: {"because", 0x2235},
+	0x01002247: {"notapproxeq", 0x2247},
+	0x01002248: {"approxeq", 0x2248},
+	0x01002262: {"notidentical", 0x2262},
+	0x01002263: {"stricteq", 0x2263},
+	0x01002800: {"braille_blank", 0x2800},
+	0x01002801: {"braille_dots_1", 0x2801},
+	0x01002802: {"braille_dots_2", 0x2802},
+	0x01002803: {"braille_dots_12", 0x2803},
+	0x01002804: {"braille_dots_3", 0x2804},
+	0x01002805: {"braille_dots_13", 0x2805},
+	0x01002806: {"braille_dots_23", 0x2806},
+	0x01002807: {"braille_dots_123", 0x2807},
+	0x01002808: {"braille_dots_4", 0x2808},
+	0x01002809: {"braille_dots_14", 0x2809},
+	0x0100280a: {"braille_dots_24", 0x280a},
+	0x0100280b: {"braille_dots_124", 0x280b},
+	0x0100280c: {"braille_dots_34", 0x280c},
+	0x0100280d: {"braille_dots_134", 0x280d},
+	0x0100280e: {"braille_dots_234", 0x280e},
+	0x0100280f: {"braille_dots_1234", 0x280f},
+	0x01002810: {"braille_dots_5", 0x2810},
+	0x01002811: {"braille_dots_15", 0x2811},
+	0x01002812: {"braille_dots_25", 0x2812},
+	0x01002813: {"braille_dots_125", 0x2813},
+	0x01002814: {"braille_dots_35", 0x2814},
+	0x01002815: {"braille_dots_135", 0x2815},
+	0x01002816: {"braille_dots_235", 0x2816},
+	0x01002817: {"braille_dots_1235", 0x2817},
+	0x01002818: {"braille_dots_45", 0x2818},
+	0x01002819: {"braille_dots_145", 0x2819},
+	0x0100281a: {"braille_dots_245", 0x281a},
+	0x0100281b: {"braille_dots_1245", 0x281b},
+	0x0100281c: {"braille_dots_345", 0x281c},
+	0x0100281d: {"braille_dots_1345", 0x281d},
+	0x0100281e: {"braille_dots_2345", 0x281e},
+	0x0100281f: {"braille_dots_12345", 0x281f},
+	0x01002820: {"braille_dots_6", 0x2820},
+	0x01002821: {"braille_dots_16", 0x2821},
+	0x01002822: {"braille_dots_26", 0x2822},
+	0x01002823: {"braille_dots_126", 0x2823},
+	0x01002824: {"braille_dots_36", 0x2824},
+	0x01002825: {"braille_dots_136", 0x2825},
+	0x01002826: {"braille_dots_236", 0x2826},
+	0x01002827: {"braille_dots_1236", 0x2827},
+	0x01002828: {"braille_dots_46", 0x2828},
+	0x01002829: {"braille_dots_146", 0x2829},
+	0x0100282a: {"braille_dots_246", 0x282a},
+	0x0100282b: {"braille_dots_1246", 0x282b},
+	0x0100282c: {"braille_dots_346", 0x282c},
+	0x0100282d: {"braille_dots_1346", 0x282d},
+	0x0100282e: {"braille_dots_2346", 0x282e},
+	0x0100282f: {"braille_dots_12346", 0x282f},
+	0x01002830: {"braille_dots_56", 0x2830},
+	0x01002831: {"braille_dots_156", 0x2831},
+	0x01002832: {"braille_dots_256", 0x2832},
+	0x01002833: {"braille_dots_1256", 0x2833},
+	0x01002834: {"braille_dots_356", 0x2834},
+	0x01002835: {"braille_dots_1356", 0x2835},
+	0x01002836: {"braille_dots_2356", 0x2836},
+	0x01002837: {"braille_dots_12356", 0x2837},
+	0x01002838: {"braille_dots_456", 0x2838},
+	0x01002839: {"braille_dots_1456", 0x2839},
+	0x0100283a: {"braille_dots_2456", 0x283a},
+	0x0100283b: {"braille_dots_12456", 0x283b},
+	0x0100283c: {"braille_dots_3456", 0x283c},
+	0x0100283d: {"braille_dots_13456", 0x283d},
+	0x0100283e: {"braille_dots_23456", 0x283e},
+	0x0100283f: {"braille_dots_123456", 0x283f},
+	0x01002840: {"braille_dots_7", 0x2840},
+	0x01002841: {"braille_dots_17", 0x2841},
+	0x01002842: {"braille_dots_27", 0x2842},
+	0x01002843: {"braille_dots_127", 0x2843},
+	0x01002844: {"braille_dots_37", 0x2844},
+	0x01002845: {"braille_dots_137", 0x2845},
+	0x01002846: {"braille_dots_237", 0x2846},
+	0x01002847: {"braille_dots_1237", 0x2847},
+	0x01002848: {"braille_dots_47", 0x2848},
+	0x01002849: {"braille_dots_147", 0x2849},
+	0x0100284a: {"braille_dots_247", 0x284a},
+	0x0100284b: {"braille_dots_1247", 0x284b},
+	0x0100284c: {"braille_dots_347", 0x284c},
+	0x0100284d: {"braille_dots_1347", 0x284d},
+	0x0100284e: {"braille_dots_2347", 0x284e},
+	0x0100284f: {"braille_dots_12347", 0x284f},
+	0x01002850: {"braille_dots_57", 0x2850},
+	0x01002851: {"braille_dots_157", 0x2851},
+	0x01002852: {"braille_dots_257", 0x2852},
+	0x01002853: {"braille_dots_1257", 0x2853},
+	0x01002854: {"braille_dots_357", 0x2854},
+	0x01002855: {"braille_dots_1357", 0x2855},
+	0x01002856: {"braille_dots_2357", 0x2856},
+	0x01002857: {"braille_dots_12357", 0x2857},
+	0x01002858: {"braille_dots_457", 0x2858},
+	0x01002859: {"braille_dots_1457", 0x2859},
+	0x0100285a: {"braille_dots_2457", 0x285a},
+	0x0100285b: {"braille_dots_12457", 0x285b},
+	0x0100285c: {"braille_dots_3457", 0x285c},
+	0x0100285d: {"braille_dots_13457", 0x285d},
+	0x0100285e: {"braille_dots_23457", 0x285e},
+	0x0100285f: {"braille_dots_123457", 0x285f},
+	0x01002860: {"braille_dots_67", 0x2860},
+	0x01002861: {"braille_dots_167", 0x2861},
+	0x01002862: {"braille_dots_267", 0x2862},
+	0x01002863: {"braille_dots_1267", 0x2863},
+	0x01002864: {"braille_dots_367", 0x2864},
+	0x01002865: {"braille_dots_1367", 0x2865},
+	0x01002866: {"braille_dots_2367", 0x2866},
+	0x01002867: {"braille_dots_12367", 0x2867},
+	0x01002868: {"braille_dots_467", 0x2868},
+	0x01002869: {"braille_dots_1467", 0x2869},
+	0x0100286a: {"braille_dots_2467", 0x286a},
+	0x0100286b: {"braille_dots_12467", 0x286b},
+	0x0100286c: {"braille_dots_3467", 0x286c},
+	0x0100286d: {"braille_dots_13467", 0x286d},
+	0x0100286e: {"braille_dots_23467", 0x286e},
+	0x0100286f: {"braille_dots_123467", 0x286f},
+	0x01002870: {"braille_dots_567", 0x2870},
+	0x01002871: {"braille_dots_1567", 0x2871},
+	0x01002872: {"braille_dots_2567", 0x2872},
+	0x01002873: {"braille_dots_12567", 0x2873},
+	0x01002874: {"braille_dots_3567", 0x2874},
+	0x01002875: {"braille_dots_13567", 0x2875},
+	0x01002876: {"braille_dots_23567", 0x2876},
+	0x01002877: {"braille_dots_123567", 0x2877},
+	0x01002878: {"braille_dots_4567", 0x2878},
+	0x01002879: {"braille_dots_14567", 0x2879},
+	0x0100287a: {"braille_dots_24567", 0x287a},
+	0x0100287b: {"braille_dots_124567", 0x287b},
+	0x0100287c: {"braille_dots_34567", 0x287c},
+	0x0100287d: {"braille_dots_134567", 0x287d},
+	0x0100287e: {"braille_dots_234567", 0x287e},
+	0x0100287f: {"braille_dots_1234567", 0x287f},
+	0x01002880: {"braille_dots_8", 0x2880},
+	0x01002881: {"braille_dots_18", 0x2881},
+	0x01002882: {"braille_dots_28", 0x2882},
+	0x01002883: {"braille_dots_128", 0x2883},
+	0x01002884: {"braille_dots_38", 0x2884},
+	0x01002885: {"braille_dots_138", 0x2885},
+	0x01002886: {"braille_dots_238", 0x2886},
+	0x01002887: {"braille_dots_1238", 0x2887},
+	0x01002888: {"braille_dots_48", 0x2888},
+	0x01002889: {"braille_dots_148", 0x2889},
+	0x0100288a: {"braille_dots_248", 0x288a},
+	0x0100288b: {"braille_dots_1248", 0x288b},
+	0x0100288c: {"braille_dots_348", 0x288c},
+	0x0100288d: {"braille_dots_1348", 0x288d},
+	0x0100288e: {"braille_dots_2348", 0x288e},
+	0x0100288f: {"braille_dots_12348", 0x288f},
+	0x01002890: {"braille_dots_58", 0x2890},
+	0x01002891: {"braille_dots_158", 0x2891},
+	0x01002892: {"braille_dots_258", 0x2892},
+	0x01002893: {"braille_dots_1258", 0x2893},
+	0x01002894: {"braille_dots_358", 0x2894},
+	0x01002895: {"braille_dots_1358", 0x2895},
+	0x01002896: {"braille_dots_2358", 0x2896},
+	0x01002897: {"braille_dots_12358", 0x2897},
+	0x01002898: {"braille_dots_458", 0x2898},
+	0x01002899: {"braille_dots_1458", 0x2899},
+	0x0100289a: {"braille_dots_2458", 0x289a},
+	0x0100289b: {"braille_dots_12458", 0x289b},
+	0x0100289c: {"braille_dots_3458", 0x289c},
+	0x0100289d: {"braille_dots_13458", 0x289d},
+	0x0100289e: {"braille_dots_23458", 0x289e},
+	0x0100289f: {"braille_dots_123458", 0x289f},
+	0x010028a0: {"braille_dots_68", 0x28a0},
+	0x010028a1: {"braille_dots_168", 0x28a1},
+	0x010028a2: {"braille_dots_268", 0x28a2},
+	0x010028a3: {"braille_dots_1268", 0x28a3},
+	0x010028a4: {"braille_dots_368", 0x28a4},
+	0x010028a5: {"braille_dots_1368", 0x28a5},
+	0x010028a6: {"braille_dots_2368", 0x28a6},
+	0x010028a7: {"braille_dots_12368", 0x28a7},
+	0x010028a8: {"braille_dots_468", 0x28a8},
+	0x010028a9: {"braille_dots_1468", 0x28a9},
+	0x010028aa: {"braille_dots_2468", 0x28aa},
+	0x010028ab: {"braille_dots_12468", 0x28ab},
+	0x010028ac: {"braille_dots_3468", 0x28ac},
+	0x010028ad: {"braille_dots_13468", 0x28ad},
+	0x010028ae: {"braille_dots_23468", 0x28ae},
+	0x010028af: {"braille_dots_123468", 0x28af},
+	0x010028b0: {"braille_dots_568", 0x28b0},
+	0x010028b1: {"braille_dots_1568", 0x28b1},
+	0x010028b2: {"braille_dots_2568", 0x28b2},
+	0x010028b3: {"braille_dots_12568", 0x28b3},
+	0x010028b4: {"braille_dots_3568", 0x28b4},
+	0x010028b5: {"braille_dots_13568", 0x28b5},
+	0x010028b6: {"braille_dots_23568", 0x28b6},
+	0x010028b7: {"braille_dots_123568", 0x28b7},
+	0x010028b8: {"braille_dots_4568", 0x28b8},
+	0x010028b9: {"braille_dots_14568", 0x28b9},
+	0x010028ba: {"braille_dots_24568", 0x28ba},
+	0x010028bb: {"braille_dots_124568", 0x28bb},
+	0x010028bc: {"braille_dots_34568", 0x28bc},
+	0x010028bd: {"braille_dots_134568", 0x28bd},
+	0x010028be: {"braille_dots_234568", 0x28be},
+	0x010028bf: {"braille_dots_1234568", 0x28bf},
+	0x010028c0: {"braille_dots_78", 0x28c0},
+	0x010028c1: {"braille_dots_178", 0x28c1},
+	0x010028c2: {"braille_dots_278", 0x28c2},
+	0x010028c3: {"braille_dots_1278", 0x28c3},
+	0x010028c4: {"braille_dots_378", 0x28c4},
+	0x010028c5: {"braille_dots_1378", 0x28c5},
+	0x010028c6: {"braille_dots_2378", 0x28c6},
+	0x010028c7: {"braille_dots_12378", 0x28c7},
+	0x010028c8: {"braille_dots_478", 0x28c8},
+	0x010028c9: {"braille_dots_1478", 0x28c9},
+	0x010028ca: {"braille_dots_2478", 0x28ca},
+	0x010028cb: {"braille_dots_12478", 0x28cb},
+	0x010028cc: {"braille_dots_3478", 0x28cc},
+	0x010028cd: {"braille_dots_13478", 0x28cd},
+	0x010028ce: {"braille_dots_23478", 0x28ce},
+	0x010028cf: {"braille_dots_123478", 0x28cf},
+	0x010028d0: {"braille_dots_578", 0x28d0},
+	0x010028d1: {"braille_dots_1578", 0x28d1},
+	0x010028d2: {"braille_dots_2578", 0x28d2},
+	0x010028d3: {"braille_dots_12578", 0x28d3},
+	0x010028d4: {"braille_dots_3578", 0x28d4},
+	0x010028d5: {"braille_dots_13578", 0x28d5},
+	0x010028d6: {"braille_dots_23578", 0x28d6},
+	0x010028d7: {"braille_dots_123578", 0x28d7},
+	0x010028d8: {"braille_dots_4578", 0x28d8},
+	0x010028d9: {"braille_dots_14578", 0x28d9},
+	0x010028da: {"braille_dots_24578", 0x28da},
+	0x010028db: {"braille_dots_124578", 0x28db},
+	0x010028dc: {"braille_dots_34578", 0x28dc},
+	0x010028dd: {"braille_dots_134578", 0x28dd},
+	0x010028de: {"braille_dots_234578", 0x28de},
+	0x010028df: {"braille_dots_1234578", 0x28df},
+	0x010028e0: {"braille_dots_678", 0x28e0},
+	0x010028e1: {"braille_dots_1678", 0x28e1},
+	0x010028e2: {"braille_dots_2678", 0x28e2},
+	0x010028e3: {"braille_dots_12678", 0x28e3},
+	0x010028e4: {"braille_dots_3678", 0x28e4},
+	0x010028e5: {"braille_dots_13678", 0x28e5},
+	0x010028e6: {"braille_dots_23678", 0x28e6},
+	0x010028e7: {"braille_dots_123678", 0x28e7},
+	0x010028e8: {"braille_dots_4678", 0x28e8},
+	0x010028e9: {"braille_dots_14678", 0x28e9},
+	0x010028ea: {"braille_dots_24678", 0x28ea},
+	0x010028eb: {"braille_dots_124678", 0x28eb},
+	0x010028ec: {"braille_dots_34678", 0x28ec},
+	0x010028ed: {"braille_dots_134678", 0x28ed},
+	0x010028ee: {"braille_dots_234678", 0x28ee},
+	0x010028ef: {"braille_dots_1234678", 0x28ef},
+	0x010028f0: {"braille_dots_5678", 0x28f0},
+	0x010028f1: {"braille_dots_15678", 0x28f1},
+	0x010028f2: {"braille_dots_25678", 0x28f2},
+	0x010028f3: {"braille_dots_125678", 0x28f3},
+	0x010028f4: {"braille_dots_35678", 0x28f4},
+	0x010028f5: {"braille_dots_135678", 0x28f5},
+	0x010028f6: {"braille_dots_235678", 0x28f6},
+	0x010028f7: {"braille_dots_1235678", 0x28f7},
+	0x010028f8: {"braille_dots_45678", 0x28f8},
+	0x010028f9: {"braille_dots_145678", 0x28f9},
+	0x010028fa: {"braille_dots_245678", 0x28fa},
+	0x010028fb: {"braille_dots_1245678", 0x28fb},
+	0x010028fc: {"braille_dots_345678", 0x28fc},
+	0x010028fd: {"braille_dots_1345678", 0x28fd},
+	0x010028fe: {"braille_dots_2345678", 0x28fe},
+	0x010028ff: {"braille_dots_12345678", 0x28ff},
+	0x1008fe01: {"XF86Switch_VT_1", 0x0000},
+	0x1008fe02: {"XF86Switch_VT_2", 0x0000},
+	0x1008fe03: {"XF86Switch_VT_3", 0x0000},
+	0x1008fe04: {"XF86Switch_VT_4", 0x0000},
+	0x1008fe05: {"XF86Switch_VT_5", 0x0000},
+	0x1008fe06: {"XF86Switch_VT_6", 0x0000},
+	0x1008fe07: {"XF86Switch_VT_7", 0x0000},
+	0x1008fe08: {"XF86Switch_VT_8", 0x0000},
+	0x1008fe09: {"XF86Switch_VT_9", 0x0000},
+	0x1008fe0a: {"XF86Switch_VT_10", 0x0000},
+	0x1008fe0b: {"XF86Switch_VT_11", 0x0000},
+	0x1008fe0c: {"XF86Switch_VT_12", 0x0000},
+	0x1008fe20: {"XF86Ungrab", 0x0000},
+	0x1008fe21: {"XF86ClearGrab", 0x0000},
+	0x1008fe22: {"XF86Next_VMode", 0x0000},
+	0x1008fe23: {"XF86Prev_VMode", 0x0000},
+	0x1008fe24: {"XF86LogWindowTree", 0x0000},
+	0x1008fe25: {"XF86LogGrabInfo", 0x0000},
+	0x1008ff01: {"XF86ModeLock", 0x0000},
+	0x1008ff02: {"XF86MonBrightnessUp", 0x0000},
+	0x1008ff03: {"XF86MonBrightnessDown", 0x0000},
+	0x1008ff04: {"XF86KbdLightOnOff", 0x0000},
+	0x1008ff05: {"XF86KbdBrightnessUp", 0x0000},
+	0x1008ff06: {"XF86KbdBrightnessDown", 0x0000},
+	0x1008ff07: {"XF86MonBrightnessCycle", 0x0000},
+	0x1008ff10: {"XF86Standby", 0x0000},
+	0x1008ff11: {"XF86AudioLowerVolume", 0x0000},
+	0x1008ff12: {"XF86AudioMute", 0x0000},
+	0x1008ff13: {"XF86AudioRaiseVolume", 0x0000},
+	0x1008ff14: {"XF86AudioPlay", 0x0000},
+	0x1008ff15: {"XF86AudioStop", 0x0000},
+	0x1008ff16: {"XF86AudioPrev", 0x0000},
+	0x1008ff17: {"XF86AudioNext", 0x0000},
+	0x1008ff18: {"XF86HomePage", 0x0000},
+	0x1008ff19: {"XF86Mail", 0x0000},
+	0x1008ff1a: {"XF86Start", 0x0000},
+	0x1008ff1b: {"XF86Search", 0x0000},
+	0x1008ff1c: {"XF86AudioRecord", 0x0000},
+	0x1008ff1d: {"XF86Calculator", 0x0000},
+	0x1008ff1e: {"XF86Memo", 0x0000},
+	0x1008ff1f: {"XF86ToDoList", 0x0000},
+	0x1008ff20: {"XF86Calendar", 0x0000},
+	0x1008ff21: {"XF86PowerDown", 0x0000},
+	0x1008ff22: {"XF86ContrastAdjust", 0x0000},
+	0x1008ff23: {"XF86RockerUp", 0x0000},
+	0x1008ff24: {"XF86RockerDown", 0x0000},
+	0x1008ff25: {"XF86RockerEnter", 0x0000},
+	0x1008ff26: {"XF86Back", 0x0000},
+	0x1008ff27: {"XF86Forward", 0x0000},
+	0x1008ff28: {"XF86Stop", 0x0000},
+	0x1008ff29: {"XF86Refresh", 0x0000},
+	0x1008ff2a: {"XF86PowerOff", 0x0000},
+	0x1008ff2b: {"XF86WakeUp", 0x0000},
+	0x1008ff2c: {"XF86Eject", 0x0000},
+	0x1008ff2d: {"XF86ScreenSaver", 0x0000},
+	0x1008ff2e: {"XF86WWW", 0x0000},
+	0x1008ff2f: {"XF86Sleep", 0x0000},
+	0x1008ff30: {"XF86Favorites", 0x0000},
+	0x1008ff31: {"XF86AudioPause", 0x0000},
+	0x1008ff32: {"XF86AudioMedia", 0x0000},
+	0x1008ff33: {"XF86MyComputer", 0x0000},
+	0x1008ff34: {"XF86VendorHome", 0x0000},
+	0x1008ff35: {"XF86LightBulb", 0x0000},
+	0x1008ff36: {"XF86Shop", 0x0000},
+	0x1008ff37: {"XF86History", 0x0000},
+	0x1008ff38: {"XF86OpenURL", 0x0000},
+	0x1008ff39: {"XF86AddFavorite", 0x0000},
+	0x1008ff3a: {"XF86HotLinks", 0x0000},
+	0x1008ff3b: {"XF86BrightnessAdjust", 0x0000},
+	0x1008ff3c: {"XF86Finance", 0x0000},
+	0x1008ff3d: {"XF86Community", 0x0000},
+	0x1008ff3e: {"XF86AudioRewind", 0x0000},
+	0x1008ff3f: {"XF86BackForward", 0x0000},
+	0x1008ff40: {"XF86Launch0", 0x0000},
+	0x1008ff41: {"XF86Launch1", 0x0000},
+	0x1008ff42: {"XF86Launch2", 0x0000},
+	0x1008ff43: {"XF86Launch3", 0x0000},
+	0x1008ff44: {"XF86Launch4", 0x0000},
+	0x1008ff45: {"XF86Launch5", 0x0000},
+	0x1008ff46: {"XF86Launch6", 0x0000},
+	0x1008ff47: {"XF86Launch7", 0x0000},
+	0x1008ff48: {"XF86Launch8", 0x0000},
+	0x1008ff49: {"XF86Launch9", 0x0000},
+	0x1008ff4a: {"XF86LaunchA", 0x0000},
+	0x1008ff4b: {"XF86LaunchB", 0x0000},
+	0x1008ff4c: {"XF86LaunchC", 0x0000},
+	0x1008ff4d: {"XF86LaunchD", 0x0000},
+	0x1008ff4e: {"XF86LaunchE", 0x0000},
+	0x1008ff4f: {"XF86LaunchF", 0x0000},
+	0x1008ff50: {"XF86ApplicationLeft", 0x0000},
+	0x1008ff51: {"XF86ApplicationRight", 0x0000},
+	0x1008ff52: {"XF86Book", 0x0000},
+	0x1008ff53: {"XF86CD", 0x0000},
+	0x1008ff54: {"XF86Calculater", 0x0000},
+	0x1008ff55: {"XF86Clear", 0x0000},
+	0x1008ff56: {"XF86Close", 0x0000},
+	0x1008ff57: {"XF86Copy", 0x0000},
+	0x1008ff58: {"XF86Cut", 0x0000},
+	0x1008ff59: {"XF86Display", 0x0000},
+	0x1008ff5a: {"XF86DOS", 0x0000},
+	0x1008ff5b: {"XF86Documents", 0x0000},
+	0x1008ff5c: {"XF86Excel", 0x0000},
+	0x1008ff5d: {"XF86Explorer", 0x0000},
+	0x1008ff5e: {"XF86Game", 0x0000},
+	0x1008ff5f: {"XF86Go", 0x0000},
+	0x1008ff60: {"XF86iTouch", 0x0000},
+	0x1008ff61: {"XF86LogOff", 0x0000},
+	0x1008ff62: {"XF86Market", 0x0000},
+	0x1008ff63: {"XF86Meeting", 0x0000},
+	0x1008ff65: {"XF86MenuKB", 0x0000},
+	0x1008ff66: {"XF86MenuPB", 0x0000},
+	0x1008ff67: {"XF86MySites", 0x0000},
+	0x1008ff68: {"XF86New", 0x0000},
+	0x1008ff69: {"XF86News", 0x0000},
+	0x1008ff6a: {"XF86OfficeHome", 0x0000},
+	0x1008ff6b: {"XF86Open", 0x0000},
+	0x1008ff6c: {"XF86Option", 0x0000},
+	0x1008ff6d: {"XF86Paste", 0x0000},
+	0x1008ff6e: {"XF86Phone", 0x0000},
+	0x1008ff70: {"XF86Q", 0x0000},
+	0x1008ff72: {"XF86Reply", 0x0000},
+	0x1008ff73: {"XF86Reload", 0x0000},
+	0x1008ff74: {"XF86RotateWindows", 0x0000},
+	0x1008ff75: {"XF86RotationPB", 0x0000},
+	0x1008ff76: {"XF86RotationKB", 0x0000},
+	0x1008ff77: {"XF86Save", 0x0000},
+	0x1008ff78: {"XF86ScrollUp", 0x0000},
+	0x1008ff79: {"XF86ScrollDown", 0x0000},
+	0x1008ff7a: {"XF86ScrollClick", 0x0000},
+	0x1008ff7b: {"XF86Send", 0x0000},
+	0x1008ff7c: {"XF86Spell", 0x0000},
+	0x1008ff7d: {"XF86SplitScreen", 0x0000},
+	0x1008ff7e: {"XF86Support", 0x0000},
+	0x1008ff7f: {"XF86TaskPane", 0x0000},
+	0x1008ff80: {"XF86Terminal", 0x0000},
+	0x1008ff81: {"XF86Tools", 0x0000},
+	0x1008ff82: {"XF86Travel", 0x0000},
+	0x1008ff84: {"XF86UserPB", 0x0000},
+	0x1008ff85: {"XF86User1KB", 0x0000},
+	0x1008ff86: {"XF86User2KB", 0x0000},
+	0x1008ff87: {"XF86Video", 0x0000},
+	0x1008ff88: {"XF86WheelButton", 0x0000},
+	0x1008ff89: {"XF86Word", 0x0000},
+	0x1008ff8a: {"XF86Xfer", 0x0000},
+	0x1008ff8b: {"XF86ZoomIn", 0x0000},
+	0x1008ff8c: {"XF86ZoomOut", 0x0000},
+	0x1008ff8d: {"XF86Away", 0x0000},
+	0x1008ff8e: {"XF86Messenger", 0x0000},
+	0x1008ff8f: {"XF86WebCam", 0x0000},
+	0x1008ff90: {"XF86MailForward", 0x0000},
+	0x1008ff91: {"XF86Pictures", 0x0000},
+	0x1008ff92: {"XF86Music", 0x0000},
+	0x1008ff93: {"XF86Battery", 0x0000},
+	0x1008ff94: {"XF86Bluetooth", 0x0000},
+	0x1008ff95: {"XF86WLAN", 0x0000},
+	0x1008ff96: {"XF86UWB", 0x0000},
+	0x1008ff97: {"XF86AudioForward", 0x0000},
+	0x1008ff98: {"XF86AudioRepeat", 0x0000},
+	0x1008ff99: {"XF86AudioRandomPlay", 0x0000},
+	0x1008ff9a: {"XF86Subtitle", 0x0000},
+	0x1008ff9b: {"XF86AudioCycleTrack", 0x0000},
+	0x1008ff9c: {"XF86CycleAngle", 0x0000},
+	0x1008ff9d: {"XF86FrameBack", 0x0000},
+	0x1008ff9e: {"XF86FrameForward", 0x0000},
+	0x1008ff9f: {"XF86Time", 0x0000},
+	0x1008ffa0: {"XF86Select", 0x0000},
+	0x1008ffa1: {"XF86View", 0x0000},
+	0x1008ffa2: {"XF86TopMenu", 0x0000},
+	0x1008ffa3: {"XF86Red", 0x0000},
+	0x1008ffa4: {"XF86Green", 0x0000},
+	0x1008ffa5: {"XF86Yellow", 0x0000},
+	0x1008ffa6: {"XF86Blue", 0x0000},
+	0x1008ffa7: {"XF86Suspend", 0x0000},
+	0x1008ffa8: {"XF86Hibernate", 0x0000},
+	0x1008ffa9: {"XF86TouchpadToggle", 0x0000},
+	0x1008ffb0: {"XF86TouchpadOn", 0x0000},
+	0x1008ffb1: {"XF86TouchpadOff", 0x0000},
+	0x1008ffb2: {"XF86AudioMicMute", 0x0000},
+	0x1008ffb3: {"XF86Keyboard", 0x0000},
+	0x1008ffb4: {"XF86WWAN", 0x0000},
+	0x1008ffb5: {"XF86RFKill", 0x0000},
+	0x1008ffb6: {"XF86AudioPreset", 0x0000},
+	0x1008ffb7: {"XF86RotationLockToggle", 0x0000},
+	0x1008ffb8: {"XF86FullScreen", 0x0000},
+}
@@ -0,0 +1,80 @@
+package rfb
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+)
+
+// ParseNetworks parses networks in CIDR notation, such as 192.168.0.0/16. A
+// plain address stands for itself alone.
+func ParseNetworks(specs []string) ([]*net.IPNet, error) {
+	out := make([]*net.IPNet, 0, len(specs))
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		if !strings.Contains(spec, "/") {
+			ip := net.ParseIP(spec)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid address %q", spec)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			out = append(out, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(spec)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, network)
+	}
+	return out, nil
+}
+
+// networkAllowed returns true if the host may connect: it is in none of the
+// denied networks, and in one of the allowed ones if there are any.
+func (s *Server) networkAllowed(host string) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		// Not an IP connection, only allowed if nothing is filtered
+		return len(s.allowedNets) == 0 && len(s.deniedNets) == 0
+	}
+	if containsIP(s.deniedNets, ip) {
+		return false
+	}
+	return len(s.allowedNets) == 0 || containsIP(s.allowedNets, ip)
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, n := range networks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// remoteHost returns the address a client connects from, without the port.
+// Websocket connections report the origin as their remote address, so the
+// address of their HTTP request is used.
+func remoteHost(c net.Conn) string {
+	addr := c.RemoteAddr().String()
+	if ws, ok := c.(*websocket.Conn); ok && ws.Request() != nil {
+		addr = ws.Request().RemoteAddr
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// audit returns the audit log entry for events concerning the host.
+func (s *Server) audit(host string) *logrus.Entry {
+	return s.auditLog.WithField("remote", host)
+}
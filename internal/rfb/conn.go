@@ -13,22 +13,31 @@ import (
 type Conn struct {
 	c       net.Conn
 	s       *Server
+	host    string // remote address without the port
 	buf     *buffer.ReadWriter
 	display *display.Display
 }
 
-func (s *Server) newConn(c net.Conn) *Conn {
+func (s *Server) newConn(c net.Conn, host string) *Conn {
 	buf := buffer.NewReadWriteBuffer(c)
+	width, height := s.dimensions()
 	conn := &Conn{
-		c:   c,
-		s:   s,
-		buf: buf,
+		c:    c,
+		s:    s,
+		host: host,
+		buf:  buf,
 		display: display.NewDisplay(&display.Opts{
-			Width:           s.width,
-			Height:          s.height,
+			Width:           width,
+			Height:          height,
 			Buffer:          buf,
 			DisplayProvider: s.displayProvider,
 			GetEncodingFunc: s.GetEncoding,
+			MaxFPS:          s.maxFPS,
+			Pipeline:        s.pipeline,
+			OnResize:        s.broadcastResize,
+			ClipboardLimits: s.clipLimits,
+			Clipboard:       s.clipWatcher,
+			InputSink:       s.inputSink,
 		}),
 	}
 	return conn
@@ -43,6 +52,9 @@ func (c *Conn) serve() {
 	}
 	defer c.display.Close()
 
+	c.s.addConn(c)
+	defer c.s.removeConn(c)
+
 	// Get a map of event handlers for this connection
 	eventHandlers := c.s.GetEventHandlerMap()
 	defer events.CloseEventHandlers(eventHandlers)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/suutaku/go-vnc/internal/auth"
@@ -20,10 +21,20 @@ func (c *Conn) doHandshake() error {
 		return err
 	}
 
-	var authType auth.Type
-	if authType, err = c.negotiateAuth(ver, c.buf); err != nil {
+	authType, principal, err := c.negotiateAuth(ver, c.buf)
+	if err != nil {
 		return err
 	}
+	if perms, ok := c.s.userPermissions[principal.Username]; ok && principal.Username != "" {
+		principal.Permissions = perms
+	}
+	audit := c.s.audit(c.host).WithField("permissions", principal.Permissions.String())
+	if principal.Username != "" {
+		audit.WithField("user", principal.Username).Info("Authenticated user ", principal.Username)
+	} else {
+		audit.Info("Authenticated client")
+	}
+	c.display.SetPrincipal(principal)
 
 	logrus.Info("Reading client init")
 
@@ -60,15 +71,29 @@ func (c *Conn) doHandshake() error {
 const (
 	statusOK     = 0
 	statusFailed = 1
+	// statusTooMany is sent by TightSecurity when the client is locked out.
+	statusTooMany = 2
 )
 
 // NegotiateAuth wil negotiate authentication on the given connection, for the
-// given version.
-func (c *Conn) negotiateAuth(ver string, rw *buffer.ReadWriter) (auth.Type, error) {
+// given version. It returns the auth type used and the client it authenticated.
+func (c *Conn) negotiateAuth(ver string, rw *buffer.ReadWriter) (auth.Type, *auth.Principal, error) {
 	buf := new(bytes.Buffer)
 
 	logrus.Info("Negotiating security")
 
+	if lockout := c.s.lockouts.lockedOut(c.host); lockout > 0 {
+		c.s.audit(c.host).Warn("Connection refused during lockout")
+		// No security types, then the reason
+		reason := lockoutReason(lockout)
+		utils.Write(buf, uint8(0))
+		utils.Write(buf, uint32(len(reason)))
+		utils.Write(buf, []byte(reason))
+		rw.Dispatch(buf.Bytes())
+		rw.Flush()
+		return nil, nil, fmt.Errorf("client is locked out for %s", lockout.Round(time.Second))
+	}
+
 	utils.Write(buf, uint8(len(c.s.enabledAuthTypes)))
 	for _, t := range c.s.enabledAuthTypes {
 		utils.Write(buf, t.Code())
@@ -76,22 +101,40 @@ func (c *Conn) negotiateAuth(ver string, rw *buffer.ReadWriter) (auth.Type, erro
 	rw.Dispatch(buf.Bytes())
 	wanted, err := rw.ReadByte()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !c.s.AuthIsSupported(wanted) {
-		return nil, fmt.Errorf("client wanted unsupported auth type %d", int(wanted))
+		return nil, nil, fmt.Errorf("client wanted unsupported auth type %d", int(wanted))
 	}
 
 	authType := c.s.GetAuth(wanted)
-	logrus.Info("Using security: ", reflect.TypeOf(authType).Elem().Name())
+	authName := reflect.TypeOf(authType).Elem().Name()
+	logrus.Info("Using security: ", authName)
 
-	if err := authType.Negotiate(rw); err != nil {
-		logrus.Error("Authentication failed")
+	principal, err := authType.Negotiate(rw)
+	if err != nil {
+		audit := c.s.audit(c.host).WithField("security", authName)
+		status, reason := uint32(statusFailed), "Authentication failed"
+		if lockout := c.s.lockouts.fail(c.host); lockout > 0 {
+			audit.Warn("Authentication failed, locking out for ", lockout, ": ", err)
+			reason = lockoutReason(lockout)
+			if _, ok := authType.(*auth.TightSecurity); ok {
+				status = statusTooMany
+			}
+		} else {
+			audit.Warn("Authentication failed: ", err)
+		}
 		buf = new(bytes.Buffer)
-		utils.Write(buf, uint32(statusFailed))
+		utils.Write(buf, status)
+		if ver >= version.V8 {
+			utils.Write(buf, uint32(len(reason)))
+			utils.Write(buf, []byte(reason))
+		}
 		rw.Dispatch(buf.Bytes())
-		return nil, err
+		rw.Flush()
+		return nil, nil, err
 	}
+	c.s.lockouts.succeed(c.host)
 
 	if ver >= version.V8 {
 		// 6.1.3. SecurityResult
@@ -100,5 +143,12 @@ func (c *Conn) negotiateAuth(ver string, rw *buffer.ReadWriter) (auth.Type, erro
 		rw.Dispatch(buf.Bytes())
 	}
 
-	return authType, nil
+	return authType, principal, nil
+}
+
+// lockoutReason returns the failure reason sent to locked out clients, with the
+// time left rounded up to seconds.
+func lockoutReason(lockout time.Duration) string {
+	left := (lockout + time.Second - 1).Truncate(time.Second)
+	return fmt.Sprintf("Too many authentication failures, try again in %s", left)
 }
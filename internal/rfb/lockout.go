@@ -0,0 +1,108 @@
+package rfb
+
+import (
+	"sync"
+	"time"
+)
+
+// LockoutPolicy is how long source addresses failing to authenticate are
+// locked out for.
+type LockoutPolicy struct {
+	// MaxFailures is how many failures in a row an address may have before it
+	// is locked out. Negative disables lockouts.
+	MaxFailures int
+	// Lockout is the first lockout, doubled with every failure after it up to
+	// MaxLockout. Failures older than MaxLockout are forgotten.
+	Lockout, MaxLockout time.Duration
+}
+
+// DefaultLockoutPolicy locks an address out for 10 seconds after 5 failures,
+// and up to an hour after more.
+var DefaultLockoutPolicy = LockoutPolicy{MaxFailures: 5, Lockout: 10 * time.Second, MaxLockout: time.Hour}
+
+// lockouts counts the authentication failures of each source address.
+type lockouts struct {
+	policy LockoutPolicy
+
+	mu    sync.Mutex
+	hosts map[string]*hostFailures
+}
+
+type hostFailures struct {
+	count       int
+	last, until time.Time
+}
+
+func newLockouts(policy LockoutPolicy) *lockouts {
+	if policy.MaxFailures == 0 {
+		policy.MaxFailures = DefaultLockoutPolicy.MaxFailures
+	}
+	if policy.Lockout <= 0 {
+		policy.Lockout = DefaultLockoutPolicy.Lockout
+	}
+	if policy.MaxLockout < policy.Lockout {
+		policy.MaxLockout = policy.Lockout
+	}
+	return &lockouts{policy: policy, hosts: make(map[string]*hostFailures)}
+}
+
+// lockedOut returns how long the host remains locked out, zero if it is not.
+func (l *lockouts) lockedOut(host string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, ok := l.hosts[host]
+	if !ok {
+		return 0
+	}
+	if left := time.Until(f.until); left > 0 {
+		return left
+	}
+	return 0
+}
+
+// fail records an authentication failure of the host and returns how long it
+// is locked out for because of it, zero if it is not.
+func (l *lockouts) fail(host string) time.Duration {
+	if l.policy.MaxFailures < 0 {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	l.prune(now)
+	f, ok := l.hosts[host]
+	if !ok {
+		f = &hostFailures{}
+		l.hosts[host] = f
+	}
+	f.count++
+	f.last = now
+	if f.count <= l.policy.MaxFailures {
+		return 0
+	}
+	lockout := l.policy.Lockout
+	for i := l.policy.MaxFailures + 1; i < f.count && lockout < l.policy.MaxLockout; i++ {
+		lockout *= 2
+	}
+	if lockout > l.policy.MaxLockout {
+		lockout = l.policy.MaxLockout
+	}
+	f.until = now.Add(lockout)
+	return lockout
+}
+
+// succeed forgets the failures of the host.
+func (l *lockouts) succeed(host string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.hosts, host)
+}
+
+// prune forgets hosts that are not locked out and have not failed for a while.
+func (l *lockouts) prune(now time.Time) {
+	for host, f := range l.hosts {
+		if now.After(f.until) && now.Sub(f.last) > l.policy.MaxLockout {
+			delete(l.hosts, host)
+		}
+	}
+}
@@ -1,19 +1,25 @@
 package rfb
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
 	"net"
 	"net/http"
 	"reflect"
+	"sync"
 	"time"
 
 	"golang.org/x/net/websocket"
 
-	"github.com/go-vgo/robotgo"
 	"github.com/sirupsen/logrus"
 	"github.com/suutaku/go-vnc/internal/auth"
+	"github.com/suutaku/go-vnc/internal/clipboard"
 	"github.com/suutaku/go-vnc/internal/display"
 	"github.com/suutaku/go-vnc/internal/encodings"
 	"github.com/suutaku/go-vnc/internal/events"
+	"github.com/suutaku/go-vnc/internal/input"
+	"github.com/suutaku/go-vnc/internal/types"
 )
 
 // ServerOpts represents options that can be used to configure a new RFB server.
@@ -24,23 +30,97 @@ type ServerOpts struct {
 	EnabledEncodings []encodings.Encoding
 	EnabledAuthTypes []auth.Type
 	EnabledEvents    []events.Event
+	// MaxFPS caps the rate of framebuffer updates sent to each client, zero
+	// means no cap.
+	MaxFPS int
+	// FrameRate is the rate of display providers generating frames, such as
+	// the test pattern. Zero keeps their default.
+	FrameRate int
+	// ClipboardLimits are the largest clipboard data accepted from clients per
+	// format, clipboard.DefaultLimits if nil.
+	ClipboardLimits clipboard.Limits
+	// Clipboard is the host clipboard shared with clients, the clipboard of
+	// the input sink if nil.
+	Clipboard clipboard.Clipboard
+	// InputSink receives the input of all clients, the desktop the server
+	// runs on if nil.
+	InputSink input.Sink
+	// TLSConfig holds the certificate of VeNCrypt's X509 subtypes, which are
+	// not offered without one.
+	TLSConfig *tls.Config
+	// VeNCryptSubtypes are the VeNCrypt subtypes offered, in order of
+	// preference. The X509 ones but None if empty, and X509None too if the
+	// None auth type is enabled. VeNCrypt is disabled if it has nothing to
+	// offer.
+	VeNCryptSubtypes []uint32
+	// HostKey identifies the server to clients of the RSA-AES types. A new
+	// key is generated at startup if nil, so clients see a different one each
+	// time.
+	HostKey *rsa.PrivateKey
+	// Authenticator checks the usernames and passwords of clients, replacing
+	// ServerPassword for every auth type if set.
+	Authenticator auth.Authenticator
+	// ViewOnlyPassword is a second shared password that only lets clients
+	// watch. None if empty.
+	ViewOnlyPassword string
+	// UserPermissions are the permissions of the users of the Authenticator.
+	// Users not in it have full control.
+	UserPermissions map[string]auth.Permissions
+	// Lockout is how long addresses failing to authenticate are locked out
+	// for, DefaultLockoutPolicy if nil.
+	Lockout *LockoutPolicy
+	// AllowedNetworks are the only networks clients may connect from, if any.
+	AllowedNetworks []*net.IPNet
+	// DeniedNetworks are networks clients may not connect from.
+	DeniedNetworks []*net.IPNet
+	// AuditLog receives refused connections and the results of
+	// authentication, the standard logger if nil.
+	AuditLog logrus.FieldLogger
 }
 
 // NewServer creates a new RFB server with an initial width and height.
 func NewServer(opts *ServerOpts) *Server {
 	if opts.Width <= 0 || opts.Height <= 0 {
-		opts.Width, opts.Height = robotgo.GetScreenSize()
+		opts.Width, opts.Height = display.DefaultSize(opts.DisplayProvider)
 	}
 	server := &Server{
 		displayProvider:  opts.DisplayProvider,
 		width:            opts.Width,
 		height:           opts.Height,
 		serverPassword:   opts.ServerPassword,
+		viewOnlyPassword: opts.ViewOnlyPassword,
+		userPermissions:  opts.UserPermissions,
 		enabledEncodings: opts.EnabledEncodings,
 		enabledAuthTypes: opts.EnabledAuthTypes,
 		enabledEvents:    opts.EnabledEvents,
+		maxFPS:           opts.MaxFPS,
+		clipLimits:       opts.ClipboardLimits,
+		pipeline:         display.NewPipeline(opts.DisplayProvider, opts.FrameRate),
+		inputSink:        opts.InputSink,
+		conns:            make(map[*Conn]struct{}),
+		allowedNets:      opts.AllowedNetworks,
+		deniedNets:       opts.DeniedNetworks,
+		auditLog:         opts.AuditLog,
 	}
 
+	lockout := DefaultLockoutPolicy
+	if opts.Lockout != nil {
+		lockout = *opts.Lockout
+	}
+	server.lockouts = newLockouts(lockout)
+	if server.auditLog == nil {
+		server.auditLog = logrus.StandardLogger()
+	}
+
+	if server.inputSink == nil {
+		server.inputSink = &input.Robotgo{}
+	}
+	cb := opts.Clipboard
+	if cb == nil {
+		cb = server.inputSink.Clipboard()
+	}
+	server.clipWatcher = clipboard.NewWatcher(cb, clipboard.DefaultPollInterval, server.broadcastClipboard)
+
 	// Configure default events if any are empty
 	if len(opts.EnabledEncodings) == 0 {
 		server.enabledEncodings = encodings.GetDefaults()
@@ -65,6 +145,57 @@ func NewServer(opts *ServerOpts) *Server {
 		iface := server.GetAuthByName("VNCAuth")
 		vncAuth := iface.(*auth.VNCAuth)
 		vncAuth.Password = server.serverPassword
+		vncAuth.ViewOnlyPassword = server.viewOnlyPassword
+		vncAuth.Authenticator = opts.Authenticator
+		if _, ok := opts.Authenticator.(auth.VNCPasswordLookup); opts.Authenticator != nil && !ok {
+			logrus.Warn("VNCAuth is enabled but cannot check the passwords of the authenticator, nobody can use it")
+		}
+	}
+
+	// Configure VeNCrypt if enabled
+	if iface := server.GetAuthByName("VeNCrypt"); iface != nil {
+		vencrypt := iface.(*auth.VeNCrypt)
+		vencrypt.Password = server.serverPassword
+		vencrypt.ViewOnlyPassword = server.viewOnlyPassword
+		vencrypt.TLSConfig = opts.TLSConfig
+		vencrypt.Subtypes = opts.VeNCryptSubtypes
+		vencrypt.AllowNone = server.GetAuthByName("None") != nil
+		vencrypt.Authenticator = opts.Authenticator
+		offered := vencrypt.ServerSubtypes()
+		if len(offered) == 0 {
+			logrus.Warn("VeNCrypt is enabled but has no subtype to offer without a certificate, disabling it")
+			server.disableAuth(vencrypt)
+		}
+		for _, st := range offered {
+			if st == auth.VeNCryptTLSNone || st == auth.VeNCryptTLSVnc || st == auth.VeNCryptTLSPlain {
+				logrus.Warn("VeNCrypt offers TLS subtypes, which TigerVNC, gtk-vnc and other standard clients cannot use")
+				break
+			}
+		}
+	}
+
+	// Configure the RSA-AES types if enabled
+	hostKey := opts.HostKey
+	for _, t := range server.enabledAuthTypes {
+		rsaAES, ok := t.(interface{ Settings() *auth.RSAAES })
+		if !ok {
+			continue
+		}
+		if hostKey == nil {
+			logrus.Warn("RSA-AES is enabled and no host key provided, generating one for this run")
+			key, err := rsa.GenerateKey(rand.Reader, auth.DefaultHostKeyBits)
+			if err != nil {
+				logrus.Error("Could not generate an RSA-AES host key: ", err)
+				break
+			}
+			hostKey = key
+			logrus.Info("RSA-AES host key fingerprint: ", auth.HostKeyFingerprint(&hostKey.PublicKey))
+		}
+		settings := rsaAES.Settings()
+		settings.HostKey = hostKey
+		settings.Password = server.serverPassword
+		settings.ViewOnlyPassword = server.viewOnlyPassword
+		settings.Authenticator = opts.Authenticator
 	}
 
 	return server
@@ -75,10 +206,27 @@ func NewServer(opts *ServerOpts) *Server {
 type Server struct {
 	width, height    int
 	serverPassword   string
+	viewOnlyPassword string
+	userPermissions  map[string]auth.Permissions
 	displayProvider  display.Provider
 	enabledEncodings []encodings.Encoding
 	enabledAuthTypes []auth.Type
 	enabledEvents    []events.Event
+	maxFPS           int
+	pipeline         *display.Pipeline
+	clipLimits       clipboard.Limits
+	clipWatcher      *clipboard.Watcher
+	inputSink        input.Sink
+
+	// Connected clients, which are told when one of them resizes the display.
+	connsMu sync.Mutex
+	conns   map[*Conn]struct{}
+
+	// Who may connect, and the failures of those who tried.
+	allowedNets []*net.IPNet
+	deniedNets  []*net.IPNet
+	lockouts    *lockouts
+	auditLog    logrus.FieldLogger
 }
 
 // Serve binds the RFB server to the given listener and starts serving connections.
@@ -93,9 +241,16 @@ func (s *Server) Serve(ln net.Listener) error {
 
 		logrus.Info("New client connection from ", c.RemoteAddr().String())
 
+		host := remoteHost(c)
+		if !s.networkAllowed(host) {
+			s.audit(host).Warn("Connection refused by the network lists")
+			c.Close()
+			continue
+		}
+
 		// Create a new client connection
 
-		conn := s.newConn(c)
+		conn := s.newConn(c, host)
 
 		// Do the rfb handshake
 		logrus.Debug("start handleshake")
@@ -119,8 +274,14 @@ func (s *Server) ServeWebsockify(ln net.Listener) error {
 			Handshake: func(cfg *websocket.Config, r *http.Request) error { return nil },
 			Handler: func(wsconn *websocket.Conn) {
 				wsconn.PayloadType = websocket.BinaryFrame
+				host := remoteHost(wsconn)
+				if !s.networkAllowed(host) {
+					s.audit(host).Warn("Websockify connection refused by the network lists")
+					wsconn.Close()
+					return
+				}
 				// Create a new client connection
-				conn := s.newConn(wsconn)
+				conn := s.newConn(wsconn, host)
 				// Do the rfb handshake
 				if err := conn.doHandshake(); err != nil {
 					logrus.Error("Error during server-client handshake: ", err.Error())
@@ -136,6 +297,54 @@ func (s *Server) ServeWebsockify(ln net.Listener) error {
 	return nil
 }
 
+// addConn registers a client that finished the handshake.
+func (s *Server) addConn(c *Conn) {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	s.conns[c] = struct{}{}
+	s.clipWatcher.Start()
+}
+
+// removeConn unregisters a client. It must be called before its display is
+// closed.
+func (s *Server) removeConn(c *Conn) {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	delete(s.conns, c)
+}
+
+// dimensions returns the framebuffer size for new clients.
+func (s *Server) dimensions() (width, height int) {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	return s.width, s.height
+}
+
+// broadcastResize is called when a client changed the framebuffer size. New
+// clients start with that size and the other connected clients follow it.
+func (s *Server) broadcastResize(origin *display.Display, width, height int, screens []types.Screen) {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	s.width, s.height = width, height
+	for c := range s.conns {
+		if c.display != origin {
+			c.display.DispatchLayoutChange(width, height, screens)
+		}
+	}
+}
+
+// broadcastClipboard sends new clipboard contents to every client except the
+// one they came from.
+func (s *Server) broadcastClipboard(c clipboard.Contents, origin interface{}) {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	for conn := range s.conns {
+		if conn.display != origin {
+			conn.display.SendClipboard(c)
+		}
+	}
+}
+
 // AuthIsSupported returns true if the given auth type is supported.
 func (s *Server) AuthIsSupported(code uint8) bool {
 	for _, t := range s.enabledAuthTypes {
@@ -190,6 +399,17 @@ func (s *Server) GetAuthByName(name string) auth.Type {
 	return nil
 }
 
+// disableAuth removes an auth type from the ones offered to clients.
+func (s *Server) disableAuth(a auth.Type) {
+	out := make([]auth.Type, 0, len(s.enabledAuthTypes))
+	for _, t := range s.enabledAuthTypes {
+		if t != a {
+			out = append(out, t)
+		}
+	}
+	s.enabledAuthTypes = out
+}
+
 // GetEventHandlerMap returns a map that can be used for handling events on an
 // rfb connection.
 func (s *Server) GetEventHandlerMap() map[uint8]events.Event {
@@ -30,6 +30,9 @@ func (r *FrameBufferUpdateRequest) Incremental() bool { return r.IncrementalFlag
 type KeyEvent struct {
 	DownFlag uint8
 	Key      uint32
+	// Scancode is the XT scancode of the key if the client sent one with a
+	// QEMU extended key event, zero otherwise.
+	Scancode uint32
 }
 
 // IsDown returns true if the event is a down event.
@@ -53,3 +56,53 @@ type ClientCutText struct {
 	Length uint32
 	Text   []uint8
 }
+
+// EnableContinuousUpdates is a message asking the server to start or stop sending
+// updates for an area without waiting for update requests.
+type EnableContinuousUpdates struct {
+	EnableFlag          uint8
+	X, Y, Width, Height uint16
+}
+
+// Enabled returns true if the client wants continuous updates.
+func (e *EnableContinuousUpdates) Enabled() bool { return e.EnableFlag != 0 }
+
+// Fence flags.
+const (
+	FenceBlockBefore uint32 = 1 << 0
+	FenceBlockAfter  uint32 = 1 << 1
+	FenceSyncNext    uint32 = 1 << 2
+	FenceRequest     uint32 = 1 << 31
+
+	// FenceSupportedFlags are the flags the server honours in fence requests.
+	FenceSupportedFlags = FenceBlockBefore | FenceBlockAfter | FenceSyncNext
+)
+
+// FenceMaxPayload is the largest payload a fence message may carry.
+const FenceMaxPayload = 64
+
+// Fence is a message used to synchronise the client and server message streams.
+// It is sent in both directions, and a fence with the request flag set is
+// returned by the other side.
+type Fence struct {
+	Flags   uint32
+	Payload []byte
+}
+
+// IsRequest returns true if the fence has to be answered.
+func (f *Fence) IsRequest() bool { return f.Flags&FenceRequest != 0 }
+
+// Screen is an entry of the screen layout sent with ExtendedDesktopSize and
+// SetDesktopSize.
+type Screen struct {
+	ID                  uint32
+	X, Y, Width, Height uint16
+	Flags               uint32
+}
+
+// SetDesktopSize is a message asking the server to change the framebuffer size
+// and screen layout.
+type SetDesktopSize struct {
+	Width, Height uint16
+	Screens       []Screen
+}
@@ -19,7 +19,7 @@ func NegotiateProtocolVersion(buf *buffer.ReadWriter) (string, error) {
 	logrus.Debug("do handshke dispatch version done")
 	sl, err := buf.Reader().ReadSlice('\n')
 	if err != nil {
-		logrus.Debug("reading client protocol version: %v", err)
+		logrus.Debugf("reading client protocol version: %v", err)
 		return "", fmt.Errorf("reading client protocol version: %v", err)
 	}
 	ver := string(sl)
@@ -2,6 +2,7 @@ package config
 
 import (
 	"github.com/suutaku/go-vnc/internal/display"
+	"github.com/suutaku/go-vnc/internal/input"
 	"github.com/suutaku/go-vnc/internal/utils"
 )
 
@@ -18,22 +19,70 @@ type TCPConf struct {
 	Port int32
 }
 
+// TLSConf holds the PEM encoded certificate and key of VeNCrypt's X509
+// subtypes.
+type TLSConf struct {
+	CertFile string
+	KeyFile  string
+}
+
 type ResolutionConf struct {
 	Width  int32
 	Height int32
 }
 
+// ClipboardLimitConf holds the largest clipboard data accepted per format, in
+// bytes. Zero keeps the default.
+type ClipboardLimitConf struct {
+	Text int
+	RTF  int
+	HTML int
+}
+
+// LockoutConf is how long addresses failing to authenticate are locked out
+// for: Seconds after MaxFailures failures, doubled with every failure after it
+// up to MaxSeconds. Zero keeps the default, a negative MaxFailures disables
+// lockouts.
+type LockoutConf struct {
+	MaxFailures int
+	Seconds     int
+	MaxSeconds  int
+}
+
 type Configure struct {
 	Debug        bool
 	TCP          TCPConf
 	Resolution   ResolutionConf
-	AuthFilePath string
-	DisplayImpl  string
+	AuthFilePath string            // htpasswd file of bcrypt hashed user passwords
+	Users        map[string]string // usernames and passwords, if there is no AuthFilePath
+	DisplayImpl  string            // screenshot, testpattern
+	InputSink    string            // robotgo, recorder
 	Websockify   WebsockifyConf
 	AuthType     []string
 	EncodingType []string
 	EventType    []string
 	Password     string
+	MaxFPS       int // zero means no cap
+	FrameRate    int // of generated displays such as testpattern, zero for their default
+	Clipboard    ClipboardLimitConf
+	TLS          TLSConf
+	HostKeyFile  string // RSA host key of the RSA-AES auth types, created if missing
+	// VeNCryptSubtypes offered, such as X509Plain or TLSVnc. The TLS ones are
+	// only offered if listed here, and X509None if listed or if the None auth
+	// type is enabled.
+	VeNCryptSubtypes []string
+	// ViewOnlyPassword is a second password that only lets clients watch.
+	ViewOnlyPassword string
+	// Permissions of users by name: view-only, keyboard, pointer,
+	// clipboard-in, clipboard-out, resize or full. Users not listed have full
+	// control.
+	Permissions map[string][]string
+	Lockout     LockoutConf
+	// Networks clients may connect from, in CIDR notation or as single
+	// addresses. All but the denied ones if AllowedNetworks is empty.
+	AllowedNetworks []string
+	DeniedNetworks  []string
+	AuditLogFile    string // refused connections and authentication results, the main log if empty
 }
 
 var DefaultConfigure = Configure{
@@ -44,7 +93,8 @@ var DefaultConfigure = Configure{
 	},
 	Password:     utils.RandomString(8),
 	DisplayImpl:  display.ProviderScreenShot,
-	AuthType:     []string{"VNCAuth", "TightSecurity"}, // None, VNCAuth, TightSecurity
-	EncodingType: []string{"TightPNGEncoding", "RawEncoding", "TightEncoding"},
-	EventType:    []string{"KeyEvent", "PointerEvent", "FrameBufferUpdate", "SetPixelFormat", "SetEncodings", "ClientCutText"},
+	InputSink:    input.SinkRobotgo,
+	AuthType:     []string{"VNCAuth", "TightSecurity"}, // None, VNCAuth, TightSecurity, VeNCrypt, RA2, RA2ne, RA256, RAne256, RA2TwoStep, RA256TwoStep
+	EncodingType: []string{"TightPNGEncoding", "RawEncoding", "TightEncoding", "HextileEncoding", "ZRLEEncoding"},
+	EventType:    []string{"KeyEvent", "PointerEvent", "FrameBufferUpdate", "SetPixelFormat", "SetEncodings", "ClientCutText", "EnableContinuousUpdates", "Fence", "SetDesktopSize", "QEMUClientMessage"},
 }
@@ -0,0 +1,54 @@
+package vnc
+
+import "github.com/suutaku/go-vnc/internal/display"
+
+// DisplayProvider is a source of frames for connected clients. One provider is
+// shared by all the connections of a server: it is created and started with
+// the initial framebuffer size when the first client connects, and closed after
+// the last one disconnects.
+//
+// PullFrame is called in a loop, so it must block until a new frame exists,
+// unless the provider reports its changes as a DamageProvider, in which case
+// it is only called after a change. Returning nil means the provider stopped,
+// it is not closed again and a new one is started for the next client.
+//
+// Providers may also implement DisplayResizer and CursorProvider.
+type DisplayProvider = display.IDisplay
+
+// DamageProvider is implemented by providers that know when and where their
+// frames change.
+type DamageProvider = display.DamageProvider
+
+// DisplayResizer is implemented by providers that can change the size of their
+// frames when a client asks for it.
+type DisplayResizer = display.Resizer
+
+// CursorProvider is implemented by providers that know about the pointer, so
+// that clients can draw it themselves.
+type CursorProvider = display.CursorProvider
+
+// Cursor is the shape of the pointer.
+type Cursor = display.Cursor
+
+// RegisterDisplayProvider makes a provider available under the given name for
+// config.Configure.DisplayImpl.
+func RegisterDisplayProvider(name string, factory func() DisplayProvider) {
+	display.RegisterProvider(display.Provider(name), factory)
+}
+
+// Framebuffer is a display drawn by a Go application. Clients are updated with
+// the areas passed to MarkDirty, and their input goes to its callbacks instead
+// of the desktop.
+type Framebuffer = display.Framebuffer
+
+// NewFramebuffer returns a black framebuffer of the given size.
+func NewFramebuffer(width, height int) *Framebuffer { return display.NewFramebuffer(width, height) }
+
+// RegisterFramebuffer makes the framebuffer available under the given name as
+// both a display provider and an input sink. Select it by setting
+// config.Configure.DisplayImpl and InputSink to the name, and the resolution
+// to its size.
+func RegisterFramebuffer(name string, fb *Framebuffer) {
+	RegisterDisplayProvider(name, fb.Provider)
+	RegisterInputSink(name, fb.InputSink)
+}
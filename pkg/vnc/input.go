@@ -0,0 +1,39 @@
+package vnc
+
+import (
+	"github.com/suutaku/go-vnc/internal/input"
+	"github.com/suutaku/go-vnc/internal/keysym"
+)
+
+// InputSink receives the keyboard, pointer and clipboard input of clients. It
+// is selected by name with config.Configure.InputSink.
+type InputSink = input.Sink
+
+// Button is a pointer button passed to an InputSink.
+type Button = input.Button
+
+// Pointer buttons.
+const (
+	ButtonLeft   = input.ButtonLeft
+	ButtonMiddle = input.ButtonMiddle
+	ButtonRight  = input.ButtonRight
+)
+
+// InputRecorder is an InputSink that records input in memory, for tests.
+type InputRecorder = input.Recorder
+
+// InputEvent is an input event recorded by an InputRecorder.
+type InputEvent = input.Event
+
+// NewInputRecorder returns a new InputRecorder.
+func NewInputRecorder() *InputRecorder { return input.NewRecorder() }
+
+// RegisterInputSink makes a sink available under the given name for
+// config.Configure.InputSink. The factory is called for each server.
+func RegisterInputSink(name string, factory func() InputSink) {
+	input.Register(input.Type(name), factory)
+}
+
+// KeysymRune returns the character an X11 keysym, as passed to an InputSink or
+// Framebuffer.OnKey, produces.
+func KeysymRune(ks uint32) (rune, bool) { return keysym.Rune(ks) }
@@ -1,11 +1,22 @@
 package vnc
 
 import (
+	"crypto/rsa"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
 	"reflect"
+	"time"
 
+	"github.com/sirupsen/logrus"
 	"github.com/suutaku/go-vnc/internal/auth"
+	"github.com/suutaku/go-vnc/internal/clipboard"
 	"github.com/suutaku/go-vnc/internal/encodings"
 	"github.com/suutaku/go-vnc/internal/events"
+	"github.com/suutaku/go-vnc/internal/input"
+	"github.com/suutaku/go-vnc/internal/rfb"
+	"github.com/suutaku/go-vnc/pkg/config"
 )
 
 func addAuthType(name string) *auth.Type {
@@ -76,3 +87,138 @@ func authIsEnabled(tt []auth.Type, name string) bool {
 	}
 	return false
 }
+
+// passwordIsNeeded returns true if one of the auth types checks the server
+// password.
+func passwordIsNeeded(tt []auth.Type) bool {
+	for _, name := range []string{"VNCAuth", "VeNCrypt", "RA2", "RA2ne", "RA256", "RAne256", "RA2TwoStep", "RA256TwoStep"} {
+		if authIsEnabled(tt, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func configureClipboardLimits(conf config.ClipboardLimitConf) clipboard.Limits {
+	limits := make(clipboard.Limits)
+	for format, size := range clipboard.DefaultLimits {
+		limits[format] = size
+	}
+	for format, size := range map[uint32]int{
+		clipboard.FormatText: conf.Text,
+		clipboard.FormatRTF:  conf.RTF,
+		clipboard.FormatHTML: conf.HTML,
+	} {
+		if size > 0 {
+			limits[format] = uint32(size)
+		}
+	}
+	return limits
+}
+
+func configureInputSink(name string) input.Sink {
+	if name == "" {
+		return nil
+	}
+	sink := input.GetSink(input.Type(name))
+	if sink == nil {
+		logrus.Warn("Unknown input sink ", name, ", using ", input.SinkRobotgo)
+	}
+	return sink
+}
+
+func configureTLS(conf config.TLSConf) *tls.Config {
+	if conf.CertFile == "" && conf.KeyFile == "" {
+		return nil
+	}
+	cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		panic(err)
+	}
+	return &tls.Config{Certificates: []tls.Certificate{cert}}
+}
+
+func configureVeNCryptSubtypes(names []string) []uint32 {
+	subtypes, err := auth.ParseVeNCryptSubtypes(names)
+	if err != nil {
+		panic(err)
+	}
+	return subtypes
+}
+
+func configureHostKey(path string) *rsa.PrivateKey {
+	if path == "" {
+		return nil
+	}
+	key, err := auth.LoadHostKey(path)
+	if err != nil {
+		panic(err)
+	}
+	logrus.Info("RSA-AES host key fingerprint: ", auth.HostKeyFingerprint(&key.PublicKey))
+	return key
+}
+
+func configurePermissions(users map[string][]string) map[string]auth.Permissions {
+	if len(users) == 0 {
+		return nil
+	}
+	out := make(map[string]auth.Permissions, len(users))
+	for user, names := range users {
+		perms, err := auth.ParsePermissions(names)
+		if err != nil {
+			panic(fmt.Errorf("permissions of %s: %v", user, err))
+		}
+		out[user] = perms
+	}
+	return out
+}
+
+func configureLockout(conf config.LockoutConf) *rfb.LockoutPolicy {
+	policy := rfb.DefaultLockoutPolicy
+	if conf.MaxFailures != 0 {
+		policy.MaxFailures = conf.MaxFailures
+	}
+	if conf.Seconds > 0 {
+		policy.Lockout = time.Duration(conf.Seconds) * time.Second
+	}
+	if conf.MaxSeconds > 0 {
+		policy.MaxLockout = time.Duration(conf.MaxSeconds) * time.Second
+	}
+	return &policy
+}
+
+func configureNetworks(specs []string) []*net.IPNet {
+	networks, err := rfb.ParseNetworks(specs)
+	if err != nil {
+		panic(err)
+	}
+	return networks
+}
+
+func configureAuditLog(path string) logrus.FieldLogger {
+	if path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		panic(err)
+	}
+	logger := logrus.New()
+	logger.Out = f
+	logger.Formatter = &logrus.JSONFormatter{}
+	return logger
+}
+
+func configureAuthenticator(conf config.Configure) auth.Authenticator {
+	if conf.AuthFilePath != "" {
+		a, err := auth.NewHtpasswdAuthenticator(conf.AuthFilePath)
+		if err != nil {
+			panic(err)
+		}
+		return a
+	}
+	if len(conf.Users) > 0 {
+		return auth.NewStaticAuthenticator(conf.Users)
+	}
+	return nil
+}
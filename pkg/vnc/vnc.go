@@ -27,9 +27,23 @@ func NewVNC(ctx context.Context, conf config.Configure) *VNC {
 		EnabledEncodings: configureEncodings(conf.EncodingType),
 		EnabledEvents:    configureEvents(conf.EventType),
 		ServerPassword:   conf.Password,
+		MaxFPS:           conf.MaxFPS,
+		FrameRate:        conf.FrameRate,
+		ClipboardLimits:  configureClipboardLimits(conf.Clipboard),
+		InputSink:        configureInputSink(conf.InputSink),
+		TLSConfig:        configureTLS(conf.TLS),
+		VeNCryptSubtypes: configureVeNCryptSubtypes(conf.VeNCryptSubtypes),
+		HostKey:          configureHostKey(conf.HostKeyFile),
+		Authenticator:    configureAuthenticator(conf),
+		ViewOnlyPassword: conf.ViewOnlyPassword,
+		UserPermissions:  configurePermissions(conf.Permissions),
+		Lockout:          configureLockout(conf.Lockout),
+		AllowedNetworks:  configureNetworks(conf.AllowedNetworks),
+		DeniedNetworks:   configureNetworks(conf.DeniedNetworks),
+		AuditLog:         configureAuditLog(conf.AuditLogFile),
 	}
 
-	if authIsEnabled(opts.EnabledAuthTypes, "VNCAuth") {
+	if opts.Authenticator == nil && passwordIsNeeded(opts.EnabledAuthTypes) {
 		if opts.ServerPassword == "" {
 			logrus.Info("VNCAuth is enabled and no password provided, generating a server password")
 			opts.ServerPassword = utils.RandomString(8)